@@ -0,0 +1,282 @@
+// Package batting reports the plate discipline and batted balls of a batter
+// across one or many games.
+//
+// A Report tallies the batter's swings and takes, in and out of the zone and
+// with two strikes, breaks his pitches and plate appearances down by the type
+// of pitch, and joins each ball he put in play to where it was fielded on the
+// game's hit chart.
+package batting
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/ericdreeves/mlbgameday"
+	"github.com/ericdreeves/mlbgameday/pitchclass"
+)
+
+// Fields of a batted ball, by its direction relative to the batter.
+const (
+	Pull     = iota // Toward the batter's side of the field
+	Center          // Within CenterAngle of straightaway center field
+	Opposite        // Toward the other side of the field
+)
+
+// CenterAngle is the largest angle, in degrees, from straightaway center
+// field of a ball hit to Center.
+const CenterAngle = 15
+
+// The location of home plate in the coordinates of the Gameday field
+// diagram.
+const (
+	homeX = 125.42
+	homeY = 198.27
+)
+
+// Game holds the data of a game needed to report on its batters.
+type Game struct {
+	AtBats   *mlbgameday.AtBats
+	HitChart *mlbgameday.HitChart
+}
+
+// Load returns the at-bats and hit chart of a game.
+func Load(game mlbgameday.GameService) (Game, error) {
+	abs, err := game.AtBats()
+	if err != nil {
+		return Game{}, err
+	}
+	chart, err := game.HitChart()
+	if err != nil {
+		return Game{}, err
+	}
+	return Game{abs, chart}, nil
+}
+
+// TwoStrikes represents the batter's pitches seen with two strikes and the
+// plate appearances that reached two strikes.
+type TwoStrikes struct {
+	mlbgameday.Split
+
+	// Fouls is the number of pitches fouled off with two strikes.
+	Fouls int
+}
+
+// TypeStats represents the pitches of a type seen by the batter and the
+// plate appearances that ended on one.
+type TypeStats struct {
+	Type string
+	mlbgameday.Split
+}
+
+// BattedBall represents a ball put in play by the batter.
+type BattedBall struct {
+	AtBat *mlbgameday.AtBat
+	Pitch *mlbgameday.Pitch
+
+	// Type is the type of the pitch.
+	Type string
+
+	// Location is where the ball was fielded, or nil if the game's hit chart
+	// does not have it.
+	Location *mlbgameday.HitLocation
+}
+
+// SprayAngle returns the angle, in degrees, between the direction of the ball
+// from home plate and straightaway center field, positive toward right field.
+// It returns false if the ball has no location.
+func (b *BattedBall) SprayAngle() (float64, bool) {
+	if b.Location == nil {
+		return 0, false
+	}
+	x := float64(b.Location.X) - homeX
+	y := homeY - float64(b.Location.Y)
+	return math.Atan2(x, y) * 180 / math.Pi, true
+}
+
+// Field returns the field to which the ball was hit: Pull, Center or
+// Opposite. It returns false if the ball has no location.
+func (b *BattedBall) Field() (int, bool) {
+	angle, ok := b.SprayAngle()
+	if !ok {
+		return 0, false
+	}
+	if math.Abs(angle) <= CenterAngle {
+		return Center, true
+	}
+
+	// Right-handed batters pull the ball to left field.
+	if (angle < 0) == (b.AtBat.Stand != "L") {
+		return Pull, true
+	}
+	return Opposite, true
+}
+
+// Results represents the results of a set of batted balls.
+type Results struct {
+	Balls    int
+	Hits     int
+	HomeRuns int
+}
+
+// Average returns the fraction of batted balls that were hits.
+func (r Results) Average() float64 {
+	if r.Balls == 0 {
+		return 0
+	}
+	return float64(r.Hits) / float64(r.Balls)
+}
+
+// Report represents the plate discipline and batted balls of a batter.
+type Report struct {
+	Batter int
+
+	// Totals holds every plate appearance and pitch.
+	Totals     mlbgameday.Split
+	TwoStrikes TwoStrikes
+
+	// Types holds the pitches of each type, most seen first.
+	Types []TypeStats
+
+	// BattedBalls holds the balls put in play, in order of play. InPlay
+	// holds their results, and ByField those of the balls with a location,
+	// indexed by field.
+	BattedBalls []BattedBall
+	InPlay      Results
+	ByField     [3]Results
+
+	// paPitches is the number of pitches seen in plate appearances.
+	paPitches int
+}
+
+// PitchesPerPlateAppearance returns the average number of pitches the batter
+// saw in each plate appearance. Pitches of at-bats that were not plate
+// appearances, such as one that ended the inning on a runner caught
+// stealing, are not counted.
+func (r *Report) PitchesPerPlateAppearance() float64 {
+	if r.Totals.PlateAppearances == 0 {
+		return 0
+	}
+	return float64(r.paPitches) / float64(r.Totals.PlateAppearances)
+}
+
+// Build returns the report of a batter in a set of games. Pitches are typed
+// by classes; see pitchclass.Report.TypeOf. The report of a batter who did
+// not bat is empty.
+func Build(batter int, classes *pitchclass.Report, games ...Game) *Report {
+	r := &Report{Batter: batter}
+	byType := make(map[string]*TypeStats)
+	typeStats := func(t string) *TypeStats {
+		s, ok := byType[t]
+		if !ok {
+			s = &TypeStats{Type: t}
+			byType[t] = s
+		}
+		return s
+	}
+
+	for _, g := range games {
+		locations := locationsOf(g.HitChart, batter)
+		twoStrikes := false
+		mlbgameday.WalkGameStates(g.AtBats, func(step mlbgameday.GameStep) error {
+			ab := step.AtBat
+			if ab == nil || ab.Batter != batter {
+				return nil
+			}
+
+			switch step.Kind {
+			case mlbgameday.PitchStep:
+				p := step.Pitch
+				r.Totals.Add(p)
+				typeStats(classes.TypeOf(p)).Add(p)
+				if step.Before.Strikes == 2 {
+					twoStrikes = true
+					r.TwoStrikes.Add(p)
+					if strings.HasPrefix(p.Des, "Foul") && step.After.Strikes == 2 {
+						r.TwoStrikes.Fouls++
+					}
+				}
+
+			case mlbgameday.PlateAppearanceStep:
+				reached := twoStrikes
+				twoStrikes = false
+				if !ab.IsPlateAppearance() {
+					return nil
+				}
+				r.paPitches += len(ab.Pitches)
+				r.Totals.AddPlateAppearance(ab)
+				if reached {
+					r.TwoStrikes.AddPlateAppearance(ab)
+				}
+				if len(ab.Pitches) == 0 {
+					return nil
+				}
+				last := &ab.Pitches[len(ab.Pitches)-1]
+				typeStats(classes.TypeOf(last)).AddPlateAppearance(ab)
+				if last.IsInPlay() {
+					b := BattedBall{AtBat: ab, Pitch: last, Type: classes.TypeOf(last)}
+					key := locationKey{step.Before.Inning, step.Before.TopInning}
+					if ls := locations[key]; len(ls) > 0 {
+						b.Location, locations[key] = ls[0], ls[1:]
+					}
+					r.addBattedBall(b)
+				}
+			}
+			return nil
+		})
+	}
+
+	for _, s := range byType {
+		r.Types = append(r.Types, *s)
+	}
+	sort.Slice(r.Types, func(i, j int) bool {
+		if r.Types[i].Pitches != r.Types[j].Pitches {
+			return r.Types[i].Pitches > r.Types[j].Pitches
+		}
+		return r.Types[i].Type < r.Types[j].Type
+	})
+
+	return r
+}
+
+// addBattedBall adds a ball put in play.
+func (r *Report) addBattedBall(b BattedBall) {
+	r.BattedBalls = append(r.BattedBalls, b)
+
+	results := []*Results{&r.InPlay}
+	if f, ok := b.Field(); ok {
+		results = append(results, &r.ByField[f])
+	}
+	for _, res := range results {
+		res.Balls++
+		if b.AtBat.IsHit() {
+			res.Hits++
+			if b.AtBat.Event == "Home Run" {
+				res.HomeRuns++
+			}
+		}
+	}
+}
+
+// locationKey identifies the half inning of a hit location.
+type locationKey struct {
+	inning int
+	top    bool
+}
+
+// locationsOf returns the locations of the balls a batter put in play, in
+// order within each half inning.
+func locationsOf(chart *mlbgameday.HitChart, batter int) map[locationKey][]*mlbgameday.HitLocation {
+	locations := make(map[locationKey][]*mlbgameday.HitLocation)
+	if chart == nil {
+		return locations
+	}
+	for i := range chart.Hits {
+		h := &chart.Hits[i]
+		if h.Batter == batter {
+			key := locationKey{h.Inning, h.Team == "A"}
+			locations[key] = append(locations[key], h)
+		}
+	}
+	return locations
+}
@@ -0,0 +1,187 @@
+package batting
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ericdreeves/mlbgameday"
+	"github.com/ericdreeves/mlbgameday/internal/testutil"
+	"github.com/ericdreeves/mlbgameday/pitchclass"
+)
+
+func readGame(t *testing.T) Game {
+	game, err := Load(testutil.NewGame(t))
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	return game
+}
+
+func TestBuild(t *testing.T) {
+	// Jarrod Dyson, a left-handed batter, singles twice and doubles, all to
+	// left field, in 5 plate appearances.
+	r := Build(502481, nil, readGame(t))
+
+	totals := r.Totals
+	if totals.PlateAppearances != 5 || totals.Hits != 3 || totals.Walks != 0 ||
+		totals.Strikeouts != 0 {
+		t.Errorf("Build returned totals %+v, want 3 hits in 5 plate appearances", totals)
+	}
+	want := mlbgameday.Discipline{
+		Pitches:       16,
+		Located:       16,
+		InZone:        7,
+		Swings:        7,
+		ZoneSwings:    3,
+		Chases:        4,
+		Contacts:      6,
+		ZoneContacts:  3,
+		CalledStrikes: 5,
+	}
+	if totals.Discipline != want {
+		t.Errorf("Build returned discipline %+v, want %+v", totals.Discipline, want)
+	}
+	if r.PitchesPerPlateAppearance() != 3.2 {
+		t.Errorf("Report.PitchesPerPlateAppearance returned %v, want 3.2",
+			r.PitchesPerPlateAppearance())
+	}
+
+	two := r.TwoStrikes
+	if two.PlateAppearances != 3 || two.Hits != 2 || two.Pitches != 4 || two.Fouls != 0 {
+		t.Errorf("Build returned two-strike split %+v, want 2 hits in 3 plate appearances on 4 pitches",
+			two)
+	}
+
+	var testCases = []struct {
+		Type             string
+		Pitches          int
+		PlateAppearances int
+		Hits             int
+	}{
+		{"FF", 8, 3, 1},
+		{"FT", 4, 1, 1},
+		{"CH", 2, 0, 0},
+		{"CU", 1, 0, 0},
+		{"SL", 1, 1, 1},
+	}
+	if len(r.Types) != len(testCases) {
+		t.Fatalf("Build returned types %+v, want %v", r.Types, len(testCases))
+	}
+	for i, tc := range testCases {
+		s := r.Types[i]
+		if s.Type != tc.Type || s.Pitches != tc.Pitches ||
+			s.PlateAppearances != tc.PlateAppearances || s.Hits != tc.Hits {
+			t.Errorf("Build returned type %+v, want %v pitches and %v hits in %v plate appearances of %v",
+				s, tc.Pitches, tc.Hits, tc.PlateAppearances, tc.Type)
+		}
+	}
+
+	if len(r.BattedBalls) != 5 || r.InPlay != (Results{5, 3, 0}) {
+		t.Fatalf("Build returned batted balls %+v with results %+v, want 3 hits in 5",
+			r.BattedBalls, r.InPlay)
+	}
+	for _, b := range r.BattedBalls {
+		if b.Location == nil || b.Location.Des != b.AtBat.Event {
+			t.Errorf("Build joined at-bat %v to location %+v", b.AtBat.Number, b.Location)
+		}
+	}
+	if r.ByField != [3]Results{{0, 0, 0}, {1, 0, 0}, {4, 3, 0}} {
+		t.Errorf("Build returned results by field %+v, want 3 hits in 4 balls to the opposite field",
+			r.ByField)
+	}
+	if r.ByField[Opposite].Average() != 0.75 {
+		t.Errorf("Results.Average returned %v, want 0.75", r.ByField[Opposite].Average())
+	}
+}
+
+func TestBuildGames(t *testing.T) {
+	g := readGame(t)
+	one := Build(502481, nil, g)
+	two := Build(502481, nil, g, g)
+	if two.Totals.PlateAppearances != 10 || two.Totals.Pitches != 2*one.Totals.Pitches ||
+		len(two.BattedBalls) != 10 || two.ByField[Opposite].Balls != 8 {
+		t.Errorf("Build returned %+v for two games, want twice %+v", two.Totals, one.Totals)
+	}
+
+	// Without a hit chart, batted balls have no location.
+	r := Build(502481, pitchclass.NewClassifier().Classify(g.AtBats), Game{AtBats: g.AtBats})
+	if len(r.BattedBalls) != 5 || r.BattedBalls[0].Location != nil || r.ByField != [3]Results{} {
+		t.Errorf("Build returned batted balls %+v without a hit chart, want 5 without location",
+			r.BattedBalls)
+	}
+	pitches := 0
+	for _, s := range r.Types {
+		pitches += s.Pitches
+	}
+	if pitches != 16 {
+		t.Errorf("Build returned %v classified pitches, want 16", pitches)
+	}
+
+	if r := Build(1, nil, g); r.Totals.Pitches != 0 || len(r.Types) != 0 {
+		t.Errorf("Build returned %+v for a batter who did not bat, want an empty report", r)
+	}
+
+	_, err := Load(&testutil.Game{Err: errors.New("Not found")})
+	if err == nil || err.Error() != "Not found" {
+		t.Errorf("Load returned error %v, want Not found", err)
+	}
+}
+
+func TestBuildNotPlateAppearance(t *testing.T) {
+	// The first inning ends on a runner caught stealing during the first
+	// at-bat of batter 10, who leads off the second inning and grounds out.
+	abs := &mlbgameday.AtBats{Innings: []mlbgameday.AtBatInning{
+		{Number: 1, Top: []mlbgameday.AtBat{{
+			AtBatSummary: mlbgameday.AtBatSummary{
+				Number: 1, Batter: 10, Pitcher: 2, Outs: 3, Event: "Caught Stealing 2B",
+			},
+			Pitches: []mlbgameday.Pitch{{Des: "Ball", Type: "B"}, {Des: "Ball", Type: "B"}},
+		}}},
+		{Number: 2, Top: []mlbgameday.AtBat{{
+			AtBatSummary: mlbgameday.AtBatSummary{
+				Number: 2, Batter: 10, Pitcher: 2, Outs: 1, Event: "Groundout",
+			},
+			Pitches: []mlbgameday.Pitch{
+				{Des: "Called Strike", Type: "S"},
+				{Des: "In play, out(s)", Type: "X"},
+			},
+		}}},
+	}}
+
+	r := Build(10, nil, Game{AtBats: abs})
+	if r.Totals.PlateAppearances != 1 || r.Totals.Pitches != 4 {
+		t.Errorf("Build returned totals %+v, want 4 pitches in 1 plate appearance", r.Totals)
+	}
+	if r.PitchesPerPlateAppearance() != 2 {
+		t.Errorf("Report.PitchesPerPlateAppearance returned %v, want 2",
+			r.PitchesPerPlateAppearance())
+	}
+}
+
+func TestField(t *testing.T) {
+	var testCases = []struct {
+		Stand string
+		X     float32
+		Y     float32
+		Want  int
+	}{
+		{"R", 80, 150, Pull},
+		{"L", 80, 150, Opposite},
+		{"R", 170, 150, Opposite},
+		{"L", 170, 150, Pull},
+		{"R", 130, 100, Center},
+	}
+	for _, tc := range testCases {
+		b := &BattedBall{
+			AtBat:    &mlbgameday.AtBat{Stand: tc.Stand},
+			Location: &mlbgameday.HitLocation{X: tc.X, Y: tc.Y},
+		}
+		if got, ok := b.Field(); !ok || got != tc.Want {
+			t.Errorf("BattedBall.Field returned %v for %+v, want %v", got, tc, tc.Want)
+		}
+	}
+
+	if _, ok := (&BattedBall{}).Field(); ok {
+		t.Errorf("BattedBall.Field returned true without a location")
+	}
+}
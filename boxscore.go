@@ -0,0 +1,458 @@
+package mlbgameday
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BoxScore represents the box score of a game computed from its at-bats.
+type BoxScore struct {
+	Away TeamBoxScore
+	Home TeamBoxScore
+}
+
+// TeamBoxScore represents a team's batting and pitching lines in a box score.
+type TeamBoxScore struct {
+	// Batting holds a line for each player who batted or scored, in the
+	// order in which they first appeared.
+	Batting []BattingLine
+
+	// Pitching holds a line for each pitcher, in the order in which they
+	// pitched.
+	Pitching []PitchingLine
+
+	Runs int
+	Hits int
+
+	// Innings holds the team's runs in each inning it batted.
+	Innings []int
+}
+
+// BattingLine represents a player's batting statistics for a game.
+type BattingLine struct {
+	ID int
+
+	// Slot is the player's place in the batting order when he first came to
+	// the plate. It is 0 for pinch runners who did not bat.
+	Slot int
+
+	PA      int
+	AB      int
+	H       int
+	Doubles int
+	Triples int
+	HR      int
+	BB      int
+	SO      int
+	HBP     int
+	SF      int
+	RBI     int
+	R       int
+}
+
+// PitchingLine represents a pitcher's statistics for a game.
+type PitchingLine struct {
+	ID int
+
+	// Outs is the number of outs recorded while the pitcher was in the game.
+	Outs    int
+	BF      int
+	Pitches int
+	Strikes int
+	H       int
+	R       int
+	ER      int
+	BB      int
+	SO      int
+	HR      int
+}
+
+// IP returns the innings pitched in the conventional notation, e.g. "5.2"
+// for 17 outs.
+func (l PitchingLine) IP() string {
+	return fmt.Sprintf("%d.%d", l.Outs/3, l.Outs%3)
+}
+
+// ComputeBoxScore derives the box score of a game from the events and runner
+// movements of its at-bats.
+//
+// Runs, and whether they are earned, are charged to the pitcher who put the
+// runner on base, or to the pitcher on the mound if that is not known. Walks
+// and hits are charged to the pitcher who finished the plate appearance.
+func ComputeBoxScore(abs *AtBats) *BoxScore {
+	b := &boxScoreBuilder{
+		box:         new(BoxScore),
+		batters:     make(map[int]*BattingLine),
+		pitchers:    make(map[int]*PitchingLine),
+		responsible: make(map[int]int),
+	}
+	WalkGameStates(abs, func(step GameStep) error {
+		b.step(step)
+		return nil
+	})
+
+	return b.build()
+}
+
+// boxScoreBuilder accumulates the lines of a box score while walking a game.
+type boxScoreBuilder struct {
+	box *BoxScore
+
+	// batters and pitchers hold the lines of every player by ID; order holds
+	// their IDs by team in the order in which they appeared.
+	batters  map[int]*BattingLine
+	pitchers map[int]*PitchingLine
+	order    [2]struct{ batters, pitchers []int }
+
+	// responsible holds the pitcher responsible for each runner by ID.
+	responsible map[int]int
+
+	// last is the state after the most recent pitch or action of the plate
+	// appearance inProgress, from which the runs and outs of its end are
+	// counted.
+	last       GameState
+	inProgress *AtBat
+}
+
+// team returns the index in order, and the box score, of the away team if
+// away is true and of the home team otherwise.
+func (b *boxScoreBuilder) team(away bool) (int, *TeamBoxScore) {
+	if away {
+		return 0, &b.box.Away
+	}
+	return 1, &b.box.Home
+}
+
+// batter returns the line of a batter on the team batting in state s,
+// creating it if needed.
+func (b *boxScoreBuilder) batter(id int, s GameState, slot int) *BattingLine {
+	if l, ok := b.batters[id]; ok {
+		return l
+	}
+
+	i, _ := b.team(s.TopInning)
+	l := &BattingLine{ID: id, Slot: slot}
+	b.batters[id] = l
+	b.order[i].batters = append(b.order[i].batters, id)
+	return l
+}
+
+// pitcher returns the line of the pitcher on the mound in state s, creating
+// it if needed.
+func (b *boxScoreBuilder) pitcher(id int, s GameState) *PitchingLine {
+	if l, ok := b.pitchers[id]; ok {
+		return l
+	}
+
+	i, _ := b.team(!s.TopInning)
+	l := &PitchingLine{ID: id}
+	b.pitchers[id] = l
+	b.order[i].pitchers = append(b.order[i].pitchers, id)
+	return l
+}
+
+// step accumulates the statistics of a single step of a game.
+func (b *boxScoreBuilder) step(step GameStep) {
+	before, after := step.Before, step.After
+
+	// The Before state of a plate appearance precedes its pitches and
+	// actions, whose runs and outs are counted at their own steps.
+	from := before
+	if step.Kind == PlateAppearanceStep {
+		if step.AtBat == b.inProgress {
+			from = b.last
+		}
+		b.inProgress = nil
+	} else {
+		b.last, b.inProgress = after, step.AtBat
+	}
+
+	_, team := b.team(before.TopInning)
+	for len(team.Innings) < before.Inning {
+		team.Innings = append(team.Innings, 0)
+	}
+	runs := after.AwayRuns - from.AwayRuns
+	if !before.TopInning {
+		runs = after.HomeRuns - from.HomeRuns
+	}
+	team.Runs += runs
+	team.Innings[before.Inning-1] += runs
+
+	if pitcher := from.Defense().Pitcher; pitcher != 0 {
+		l := b.pitcher(pitcher, before)
+		l.Outs += after.Outs - from.Outs
+		if step.Kind == PitchStep {
+			l.Pitches++
+			if step.Pitch.Type == "S" || step.Pitch.Type == "X" {
+				l.Strikes++
+			}
+		}
+	}
+
+	if step.Kind == PlateAppearanceStep {
+		b.plateAppearance(step.AtBat, before, team)
+	}
+
+	for _, r := range step.Runners {
+		if r.Start == "" && step.AtBat != nil {
+			b.responsible[r.ID] = step.AtBat.Pitcher
+		}
+		if r.Score != "T" {
+			continue
+		}
+
+		b.batter(r.ID, before, 0).R++
+		if r.RBI == "T" && step.Kind == PlateAppearanceStep {
+			b.batter(step.AtBat.Batter, before, 0).RBI++
+		}
+
+		p := b.pitcher(b.chargedPitcher(r, before), before)
+		p.R++
+		if r.Earned == "T" {
+			p.ER++
+		}
+	}
+}
+
+// plateAppearance accumulates the result of a plate appearance.
+func (b *boxScoreBuilder) plateAppearance(ab *AtBat, before GameState, team *TeamBoxScore) {
+	if !ab.IsPlateAppearance() {
+		return
+	}
+	event := ab.Event
+
+	l := b.batter(ab.Batter, before, before.Offense().Slot)
+	p := b.pitcher(ab.Pitcher, before)
+	l.PA++
+	p.BF++
+
+	switch {
+	case ab.IsHit():
+		l.H++
+		p.H++
+		team.Hits++
+		switch event {
+		case "Double":
+			l.Doubles++
+		case "Triple":
+			l.Triples++
+		case "Home Run":
+			l.HR++
+			p.HR++
+		}
+	case ab.IsWalk():
+		l.BB++
+		p.BB++
+	case event == "Hit By Pitch":
+		l.HBP++
+	case ab.IsStrikeout():
+		l.SO++
+		p.SO++
+	case strings.HasPrefix(event, "Sac Fly"):
+		l.SF++
+	}
+
+	if isAtBat(event) {
+		l.AB++
+	}
+}
+
+// chargedPitcher returns the pitcher charged with the run of a runner who
+// scored: the pitcher who put the runner on base or, for a pinch runner, the
+// runner he replaced, or else the pitcher on the mound.
+func (b *boxScoreBuilder) chargedPitcher(r Runner, before GameState) int {
+	if p, ok := b.responsible[r.ID]; ok {
+		return p
+	}
+	if i := baseIndex(r.Start); i >= 0 {
+		if p, ok := b.responsible[before.Bases[i]]; ok {
+			return p
+		}
+	}
+	return before.Defense().Pitcher
+}
+
+// build returns the box score with its lines in order.
+func (b *boxScoreBuilder) build() *BoxScore {
+	for i, away := range []bool{true, false} {
+		_, team := b.team(away)
+		for _, id := range b.order[i].batters {
+			team.Batting = append(team.Batting, *b.batters[id])
+		}
+		for _, id := range b.order[i].pitchers {
+			team.Pitching = append(team.Pitching, *b.pitchers[id])
+		}
+	}
+
+	return b.box
+}
+
+// notPlateAppearance lists the prefixes of at-bat events that end a half
+// inning, or a game, before the batter completes his plate appearance.
+var notPlateAppearance = []string{
+	"Caught Stealing",
+	"Pickoff",
+	"Picked off",
+	"Runner Out",
+	"Stolen Base",
+	"Wild Pitch",
+	"Passed Ball",
+	"Balk",
+	"Defensive Indiff",
+	"Other Advance",
+}
+
+// IsPlateAppearance returns whether the at-bat was a completed plate
+// appearance. At-bats in progress, and those that ended the half inning on
+// the bases, such as a runner caught stealing, are not.
+func (ab *AtBat) IsPlateAppearance() bool {
+	if ab.Event == "" {
+		return false
+	}
+	for _, prefix := range notPlateAppearance {
+		if strings.HasPrefix(ab.Event, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsHit returns whether the at-bat ended in a hit.
+func (ab *AtBat) IsHit() bool {
+	return isHit(ab.Event)
+}
+
+// isHit returns whether an at-bat event is a hit.
+func isHit(event string) bool {
+	switch event {
+	case "Single", "Double", "Triple", "Home Run":
+		return true
+	}
+	return false
+}
+
+// IsWalk returns whether the at-bat ended in a walk, including an
+// intentional walk.
+func (ab *AtBat) IsWalk() bool {
+	return ab.Event == "Walk" || ab.Event == "Intent Walk"
+}
+
+// IsStrikeout returns whether the at-bat ended in a strikeout.
+func (ab *AtBat) IsStrikeout() bool {
+	return strings.HasPrefix(ab.Event, "Strikeout")
+}
+
+// isAtBat returns whether a plate appearance with the provided event counts
+// as an official at-bat.
+func isAtBat(event string) bool {
+	switch {
+	case event == "Walk", event == "Intent Walk", event == "Hit By Pitch",
+		event == "Catcher Interference",
+		strings.HasPrefix(event, "Sac"):
+		return false
+	}
+	return true
+}
+
+// Discrepancy represents a difference between a statistic in a computed box
+// score and the official one.
+type Discrepancy struct {
+	// Team is "away" or "home".
+	Team string
+
+	// Player is the ID of the player, or 0 for a team statistic.
+	Player int
+
+	// Stat names the statistic, e.g. "R", "H" or "R in inning 3".
+	Stat     string
+	Computed int
+	Official int
+}
+
+func (d Discrepancy) String() string {
+	subject := d.Team
+	if d.Player != 0 {
+		subject = fmt.Sprintf("%v player %v", d.Team, d.Player)
+	}
+	return fmt.Sprintf("%v %v: computed %v, official %v",
+		subject, d.Stat, d.Computed, d.Official)
+}
+
+// Reconcile compares the box score with the official line score and, if
+// players is not nil, with the rosters and season stats of the game's
+// players. It returns the discrepancies, which point to errors in the data
+// or in the at-bats the box score was computed from.
+//
+// A player's HR and RBI in the game may not exceed his season totals, and
+// every player in the box score must be on his team's roster, which is
+// the first of players.Teams for the away team and the second for the home
+// team.
+func (b *BoxScore) Reconcile(ls *LineScore, players *Players) []Discrepancy {
+	var ds []Discrepancy
+	check := func(team string, player int, stat string, computed, official int) {
+		if computed != official {
+			ds = append(ds, Discrepancy{team, player, stat, computed, official})
+		}
+	}
+
+	check("away", 0, "R", b.Away.Runs, ls.AwayTeamRuns)
+	check("away", 0, "H", b.Away.Hits, ls.AwayHitsRuns)
+	check("home", 0, "R", b.Home.Runs, ls.HomeTeamRuns)
+	check("home", 0, "H", b.Home.Hits, ls.HomeHitsRuns)
+
+	innings := len(ls.Innings)
+	if n := len(b.Away.Innings); n > innings {
+		innings = n
+	}
+	for i := 0; i < innings; i++ {
+		var official LineScoreInning
+		if i < len(ls.Innings) {
+			official = ls.Innings[i]
+		}
+		stat := fmt.Sprintf("R in inning %d", i+1)
+		check("away", 0, stat, inningRuns(b.Away.Innings, i), official.AwayRuns)
+		check("home", 0, stat, inningRuns(b.Home.Innings, i), official.HomeRuns)
+	}
+
+	if players == nil || len(players.Teams) != 2 {
+		return ds
+	}
+
+	for i, team := range []*TeamBoxScore{&b.Away, &b.Home} {
+		name := []string{"away", "home"}[i]
+		roster := make(map[int]Player)
+		for _, p := range players.Teams[i].Players {
+			roster[p.ID] = p
+		}
+
+		for _, l := range team.Batting {
+			p, ok := roster[l.ID]
+			if !ok {
+				check(name, l.ID, "on roster", 1, 0)
+				continue
+			}
+			if l.HR > p.HR {
+				check(name, l.ID, "HR exceeding season HR", l.HR, p.HR)
+			}
+			if l.RBI > p.RBI {
+				check(name, l.ID, "RBI exceeding season RBI", l.RBI, p.RBI)
+			}
+		}
+		for _, l := range team.Pitching {
+			if _, ok := roster[l.ID]; !ok {
+				check(name, l.ID, "on roster", 1, 0)
+			}
+		}
+	}
+
+	return ds
+}
+
+// inningRuns returns the runs in the inning at index i, or 0 for an inning
+// in which the team did not bat.
+func inningRuns(innings []int, i int) int {
+	if i < len(innings) {
+		return innings[i]
+	}
+	return 0
+}
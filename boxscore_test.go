@@ -0,0 +1,185 @@
+package mlbgameday
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComputeBoxScore(t *testing.T) {
+	abs := new(AtBats)
+	readMock(t, "./mock/inning_all.xml", abs)
+
+	got := ComputeBoxScore(abs)
+
+	if got.Away.Runs != 11 || got.Away.Hits != 16 ||
+		got.Home.Runs != 5 || got.Home.Hits != 12 {
+		t.Errorf("ComputeBoxScore returned %v R, %v H for away and %v R, %v H for home, want 11, 16 and 5, 12",
+			got.Away.Runs, got.Away.Hits, got.Home.Runs, got.Home.Hits)
+	}
+
+	wantAway := []int{0, 2, 0, 0, 3, 0, 2, 4, 0}
+	wantHome := []int{1, 0, 2, 1, 0, 0, 0, 1, 0}
+	if !reflect.DeepEqual(got.Away.Innings, wantAway) ||
+		!reflect.DeepEqual(got.Home.Innings, wantHome) {
+		t.Errorf("ComputeBoxScore returned innings %v and %v, want %v and %v",
+			got.Away.Innings, got.Home.Innings, wantAway, wantHome)
+	}
+
+	if n := len(got.Away.Batting); n != 10 {
+		t.Fatalf("ComputeBoxScore returned %v away batting lines, want 10", n)
+	}
+	wantBatting := BattingLine{543333, 3, 5, 3, 2, 0, 0, 1, 1, 0, 0, 1, 4, 2}
+	if l := got.Away.Batting[2]; l != wantBatting {
+		t.Errorf("ComputeBoxScore returned batting line %+v, want %+v",
+			l, wantBatting)
+	}
+	wantBatting = BattingLine{543257, 7, 1, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0}
+	if l := got.Home.Batting[9]; l != wantBatting {
+		t.Errorf("ComputeBoxScore returned batting line %+v, want %+v",
+			l, wantBatting)
+	}
+
+	if n := len(got.Home.Pitching); n != 6 {
+		t.Fatalf("ComputeBoxScore returned %v home pitching lines, want 6", n)
+	}
+	wantPitching := PitchingLine{621244, 15, 23, 95, 56, 9, 5, 5, 1, 6, 1}
+	if l := got.Home.Pitching[0]; l != wantPitching || l.IP() != "5.0" {
+		t.Errorf("ComputeBoxScore returned pitching line %+v, want %+v",
+			l, wantPitching)
+	}
+	outs := 0
+	for _, l := range got.Away.Pitching {
+		outs += l.Outs
+	}
+	if outs != 27 {
+		t.Errorf("ComputeBoxScore returned %v outs for the away team, want 27", outs)
+	}
+	if ip := got.Away.Pitching[0].IP(); ip != "5.1" {
+		t.Errorf("PitchingLine.IP returned %v, want 5.1", ip)
+	}
+}
+
+func TestBoxScoreReconcile(t *testing.T) {
+	abs := new(AtBats)
+	readMock(t, "./mock/inning_all.xml", abs)
+	box := ComputeBoxScore(abs)
+
+	ls := new(LineScore)
+	ls.AwayTeamRuns, ls.AwayHitsRuns = 11, 16
+	ls.HomeTeamRuns, ls.HomeHitsRuns = 5, 11
+	for i, runs := range box.Away.Innings {
+		ls.Innings = append(ls.Innings, LineScoreInning{i + 1, 0, runs})
+	}
+	for i, runs := range box.Home.Innings {
+		ls.Innings[i].HomeRuns = runs
+	}
+	ls.Innings[2].HomeRuns = 1
+
+	players := &Players{Teams: make([]Roster, 2)}
+	for i, team := range []TeamBoxScore{box.Away, box.Home} {
+		for _, l := range team.Batting {
+			players.Teams[i].Players = append(players.Teams[i].Players,
+				Player{ID: l.ID, HR: 10, RBI: 50})
+		}
+		for _, l := range team.Pitching[1:] {
+			players.Teams[i].Players = append(players.Teams[i].Players,
+				Player{ID: l.ID})
+		}
+	}
+	players.Teams[1].Players[0].HR = 2
+
+	got := box.Reconcile(ls, players)
+
+	want := []Discrepancy{
+		{"home", 0, "H", 12, 11},
+		{"home", 0, "R in inning 3", 2, 1},
+		{"away", 453178, "on roster", 1, 0},
+		{"home", 572821, "HR exceeding season HR", 3, 2},
+		{"home", 621244, "on roster", 1, 0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("BoxScore.Reconcile returned %v, want %v", got, want)
+	}
+
+	wantString := "home player 572821 HR exceeding season HR: computed 3, official 2"
+	if s := want[3].String(); s != wantString {
+		t.Errorf("Discrepancy.String returned %q, want %q", s, wantString)
+	}
+}
+
+func TestComputeBoxScoreBetweenPitches(t *testing.T) {
+	// Batter 10 triples and scores on a wild pitch to batter 11, who strikes
+	// out; batter 12 walks and is picked off during the plate appearance of
+	// batter 13, who grounds out.
+	top := []AtBat{
+		{
+			AtBatSummary: AtBatSummary{Number: 1, Batter: 10, Pitcher: 2, Event: "Triple"},
+			Pitches:      []Pitch{{Des: "In play, no out", Type: "X", EventNum: 1}},
+			EventNum:     2,
+			Runners:      []Runner{{ID: 10, End: "3B", EventNum: 2}},
+		},
+		{
+			AtBatSummary: AtBatSummary{Number: 2, Batter: 11, Pitcher: 2, Outs: 1,
+				Event: "Strikeout", AwayTeamRuns: 1},
+			Pitches: []Pitch{
+				{Des: "Ball In Dirt", Type: "B", EventNum: 3},
+				{Des: "Swinging Strike", Type: "S", EventNum: 5},
+				{Des: "Swinging Strike", Type: "S", EventNum: 6},
+				{Des: "Swinging Strike", Type: "S", EventNum: 7},
+			},
+			EventNum: 8,
+			Runners: []Runner{
+				{ID: 10, Start: "3B", EventNum: 4, Score: "T", Earned: "T"},
+			},
+		},
+		{
+			AtBatSummary: AtBatSummary{Number: 3, Batter: 12, Pitcher: 2, Outs: 1,
+				Event: "Walk", AwayTeamRuns: 1},
+			Pitches: []Pitch{
+				{Des: "Ball", Type: "B", EventNum: 9},
+				{Des: "Ball", Type: "B", EventNum: 10},
+				{Des: "Ball", Type: "B", EventNum: 11},
+				{Des: "Ball", Type: "B", EventNum: 12},
+			},
+			EventNum: 13,
+			Runners:  []Runner{{ID: 12, End: "1B", EventNum: 13}},
+		},
+		{
+			AtBatSummary: AtBatSummary{Number: 4, Batter: 13, Pitcher: 2, Outs: 3,
+				Event: "Groundout", AwayTeamRuns: 1},
+			Pitches: []Pitch{
+				{Des: "Ball", Type: "B", EventNum: 14},
+				{Des: "In play, out(s)", Type: "X", EventNum: 16},
+			},
+			EventNum: 17,
+			Runners: []Runner{
+				{ID: 12, Start: "1B", EventNum: 15},
+				{ID: 13, EventNum: 17},
+			},
+		},
+	}
+	actions := []Action{
+		{Event: "Wild Pitch", Player: 10, Outs: 0, EventNum: 4, AwayTeamRuns: 1},
+		{Event: "Pickoff 1B", Player: 12, Outs: 2, EventNum: 15, AwayTeamRuns: 1},
+	}
+	abs := &AtBats{[]AtBatInning{{Number: 1, Top: top, TopActions: actions}}}
+
+	got := ComputeBoxScore(abs)
+
+	if got.Away.Runs != 1 || !reflect.DeepEqual(got.Away.Innings, []int{1}) {
+		t.Errorf("ComputeBoxScore returned %v runs in innings %v, want 1 in the first",
+			got.Away.Runs, got.Away.Innings)
+	}
+	if n := len(got.Home.Pitching); n != 1 {
+		t.Fatalf("ComputeBoxScore returned %v home pitching lines, want 1", n)
+	}
+	if l := got.Home.Pitching[0]; l.Outs != 3 || l.R != 1 || l.ER != 1 {
+		t.Errorf("ComputeBoxScore returned pitching line %+v, want 3 outs and 1 earned run", l)
+	}
+	if l := got.Away.Batting[0]; l.ID != 10 || l.R != 1 {
+		t.Errorf("ComputeBoxScore returned batting line %+v, want 1 run for 10", l)
+	}
+	if l := got.Away.Batting[1]; l.ID != 11 || l.RBI != 0 {
+		t.Errorf("ComputeBoxScore returned batting line %+v, want no RBI for 11", l)
+	}
+}
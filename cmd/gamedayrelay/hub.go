@@ -0,0 +1,302 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ericdreeves/mlbgameday"
+)
+
+// source is the upstream of a topic: a game or a day of games.
+type source interface {
+	// watch returns the events of the source until ctx is done.
+	watch(ctx context.Context) <-chan mlbgameday.Event
+
+	// snapshot returns the current state of the source.
+	snapshot() (interface{}, error)
+}
+
+// errTopicEnded is returned when subscribing to a topic whose source is done.
+var errTopicEnded = errors.New("Topic has ended")
+
+// message is a single message sent to clients. IDs are formatted as
+// "{epoch}-{seq}", where epoch identifies the topic instance, so that IDs
+// received from a topic are never mistaken for IDs of a later topic with the
+// same key.
+type message struct {
+	ID    string
+	Event string
+	Data  json.RawMessage
+}
+
+// Hub shares one upstream watcher per topic among every client subscribed
+// to the topic.
+type Hub struct {
+	// newSource returns the source of the topic with the provided key.
+	newSource func(key string) (source, error)
+
+	// history is the number of messages kept per topic for clients that
+	// resume with a Last-Event-ID.
+	history int
+
+	// linger is how long a topic keeps watching its source after its last
+	// client leaves, so that reconnecting clients can resume.
+	linger time.Duration
+
+	mu     sync.Mutex
+	topics map[string]*topic
+}
+
+// NewHub returns a new Hub whose topics watch the sources returned by
+// newSource.
+func NewHub(newSource func(key string) (source, error)) *Hub {
+	return &Hub{
+		newSource: newSource,
+		history:   1000,
+		linger:    time.Minute,
+		topics:    make(map[string]*topic),
+	}
+}
+
+// subscribe subscribes to the topic with the provided key, starting it if
+// needed. If lastID is the ID of a message of the topic, the messages after
+// it are returned as the initial messages; otherwise, or if those messages
+// are no longer kept, a snapshot of the topic is. Later messages are sent on
+// the returned channel, which is closed when the topic ends, after an "end"
+// message, or when the subscriber falls too far behind. unsubscribe must be
+// called once the subscriber is done. If the topic has already ended,
+// errTopicEnded is returned.
+func (h *Hub) subscribe(key string, lastID string) (initial []message, ch <-chan message, unsubscribe func(), err error) {
+	h.mu.Lock()
+	t, ok := h.topics[key]
+	if !ok {
+		// Sources may make requests upstream, so they are created without
+		// holding the lock. If another client started the topic meanwhile,
+		// the new source is discarded before it is watched.
+		h.mu.Unlock()
+		src, err := h.newSource(key)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		h.mu.Lock()
+		if t, ok = h.topics[key]; !ok {
+			t = newTopic(h, key, src)
+			h.topics[key] = t
+		}
+	}
+	c, seq, missed, ok := t.add(lastID)
+	h.mu.Unlock()
+	if c == nil {
+		return nil, nil, nil, errTopicEnded
+	}
+
+	unsubscribe = func() { t.remove(c) }
+	if ok {
+		return missed, c, unsubscribe, nil
+	}
+
+	snap, err := t.snapshot(seq)
+	if err != nil {
+		unsubscribe()
+		return nil, nil, nil, err
+	}
+	return []message{snap}, c, unsubscribe, nil
+}
+
+// topic fans the events of a single source out to its subscribers.
+type topic struct {
+	hub    *Hub
+	key    string
+	src    source
+	cancel context.CancelFunc
+	epoch  string
+
+	mu      sync.Mutex
+	seq     int
+	history []message
+	subs    map[chan message]bool
+	done    bool
+	stop    *time.Timer
+
+	// snapMu serializes retrieval of snapshots, which are shared by every
+	// client that connects before the next event.
+	snapMu  sync.Mutex
+	snap    *message
+	snapSeq int
+}
+
+func newTopic(h *Hub, key string, src source) *topic {
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &topic{
+		hub:    h,
+		key:    key,
+		src:    src,
+		cancel: cancel,
+		epoch:  strconv.FormatInt(time.Now().UnixNano(), 36),
+		subs:   make(map[chan message]bool),
+	}
+
+	go t.run(ctx)
+	return t
+}
+
+// run relays the events of the topic's source to its subscribers until the
+// source is done.
+func (t *topic) run(ctx context.Context) {
+	for e := range t.src.watch(ctx) {
+		data, err := json.Marshal(e)
+		if err != nil {
+			continue
+		}
+
+		t.mu.Lock()
+		t.seq++
+		m := message{t.id(t.seq), e.Type.String(), data}
+		t.history = append(t.history, m)
+		if len(t.history) > t.hub.history {
+			t.history = t.history[len(t.history)-t.hub.history:]
+		}
+		for c := range t.subs {
+			select {
+			case c <- m:
+			default:
+				// The subscriber fell behind; it may resume from its last
+				// message.
+				delete(t.subs, c)
+				close(c)
+			}
+		}
+		t.mu.Unlock()
+	}
+
+	// Subscribers are told that the topic ended, so that clients stop
+	// reconnecting.
+	t.mu.Lock()
+	t.done = true
+	t.seq++
+	end := message{t.id(t.seq), "end", json.RawMessage("{}")}
+	for c := range t.subs {
+		select {
+		case c <- end:
+		default:
+		}
+		delete(t.subs, c)
+		close(c)
+	}
+	t.mu.Unlock()
+}
+
+// id returns the ID of the topic's message with the provided sequence
+// number.
+func (t *topic) id(seq int) string {
+	return t.epoch + "-" + strconv.Itoa(seq)
+}
+
+// seqOf returns the sequence number of the message with the provided ID. It
+// returns false if the ID is not one of the topic's.
+func (t *topic) seqOf(id string) (int, bool) {
+	i := strings.LastIndex(id, "-")
+	if i < 0 || id[:i] != t.epoch {
+		return 0, false
+	}
+
+	seq, err := strconv.Atoi(id[i+1:])
+	return seq, err == nil
+}
+
+// add registers a new subscriber. It returns the subscriber's channel, the
+// sequence number of the last message sent and, if the subscriber can resume
+// after lastID, the messages it missed. The channel is nil if the topic has
+// ended.
+func (t *topic) add(lastID string) (chan message, int, []message, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.done {
+		return nil, t.seq, nil, false
+	}
+	if t.stop != nil {
+		t.stop.Stop()
+		t.stop = nil
+	}
+
+	c := make(chan message, 64)
+	t.subs[c] = true
+
+	last, ok := t.seqOf(lastID)
+	if !ok || last > t.seq {
+		return c, t.seq, nil, false
+	}
+	first := t.seq - len(t.history) + 1
+	if last+1 < first {
+		return c, t.seq, nil, false
+	}
+
+	missed := append([]message(nil), t.history[last+1-first:]...)
+	return c, t.seq, missed, true
+}
+
+// remove unregisters a subscriber. Once a topic has no subscribers left, it
+// stops watching its source after the hub's linger duration.
+func (t *topic) remove(c chan message) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.subs[c] {
+		delete(t.subs, c)
+		close(c)
+	}
+	if len(t.subs) > 0 || t.stop != nil {
+		return
+	}
+
+	t.stop = time.AfterFunc(t.hub.linger, func() {
+		t.hub.mu.Lock()
+		defer t.hub.mu.Unlock()
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		if len(t.subs) > 0 || t.stop == nil {
+			return
+		}
+		t.cancel()
+		if t.hub.topics[t.key] == t {
+			delete(t.hub.topics, t.key)
+		}
+	})
+}
+
+// snapshot returns a snapshot message of the topic as of the message with
+// sequence number seq or later. Snapshots are only retrieved from the source again after
+// a new message.
+func (t *topic) snapshot(seq int) (message, error) {
+	t.snapMu.Lock()
+	defer t.snapMu.Unlock()
+
+	if t.snap != nil && t.snapSeq >= seq {
+		return *t.snap, nil
+	}
+
+	t.mu.Lock()
+	seq = t.seq
+	t.mu.Unlock()
+
+	v, err := t.src.snapshot()
+	if err != nil {
+		return message{}, err
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return message{}, err
+	}
+
+	t.snap = &message{t.id(seq), "snapshot", data}
+	t.snapSeq = seq
+	return *t.snap, nil
+}
@@ -0,0 +1,106 @@
+// Command gamedayrelay relays live MLB Gameday updates to browsers over
+// Server-Sent Events and WebSocket.
+//
+// Each game or day is polled once, however many clients are connected to it.
+// Clients receive a snapshot of the game's line score, or the day's
+// scoreboard, when they connect, followed by the events observed by a
+// mlbgameday.Watcher or mlbgameday.ScoreboardWatcher. Clients that reconnect
+// with a Last-Event-ID header, or a last_event_id query parameter, receive
+// the events they missed instead of a snapshot. Clients are sent an "end"
+// event once the game or day is over.
+//
+// Usage:
+//
+//	gamedayrelay [-addr :8080] [-backend gd2|statsapi] [-sport mlb]
+//
+// See Server for the endpoints.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ericdreeves/mlbgameday"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	backend := flag.String("backend", "gd2", "data source: gd2 or statsapi")
+	sport := flag.String("sport", "mlb", "sport of the days relayed")
+	flag.Parse()
+
+	var client *mlbgameday.Client
+	switch *backend {
+	case "gd2":
+		client = mlbgameday.NewClient(nil)
+	case "statsapi":
+		client = mlbgameday.NewStatsAPIClient(nil)
+	default:
+		log.Fatalf("Unknown backend %v", *backend)
+	}
+
+	hub := NewHub(clientSources(client, mlbgameday.Sport(*sport), nil))
+	log.Printf("Relaying %v Gameday updates on %v", *sport, *addr)
+	log.Fatal(http.ListenAndServe(*addr, NewServer(hub)))
+}
+
+// clientSources returns a function that creates the source of a topic from
+// the MLB Gameday API using the provided client.
+func clientSources(client *mlbgameday.Client, sport mlbgameday.Sport, opts *mlbgameday.WatchOptions) func(string) (source, error) {
+	return func(key string) (source, error) {
+		toks := strings.SplitN(key, "/", 2)
+		if len(toks) != 2 {
+			return nil, fmt.Errorf("Unknown topic %v", key)
+		}
+
+		switch toks[0] {
+		case "game":
+			game, err := client.SportGameday(sport, time.Now()).Game(toks[1])
+			if err != nil {
+				return nil, err
+			}
+			return &gameSource{game, opts}, nil
+		case "day":
+			d, err := mlbgameday.ParseGameDate(toks[1])
+			if err != nil {
+				return nil, err
+			}
+			return &daySource{client.SportGameday(sport, d.Time()), opts}, nil
+		}
+
+		return nil, fmt.Errorf("Unknown topic %v", key)
+	}
+}
+
+// gameSource is the source of a single game.
+type gameSource struct {
+	game mlbgameday.GameService
+	opts *mlbgameday.WatchOptions
+}
+
+func (s *gameSource) watch(ctx context.Context) <-chan mlbgameday.Event {
+	return mlbgameday.NewWatcher(s.game, s.opts).Watch(ctx)
+}
+
+func (s *gameSource) snapshot() (interface{}, error) {
+	return s.game.LineScore()
+}
+
+// daySource is the source of every game on a day.
+type daySource struct {
+	gameday mlbgameday.GamedayService
+	opts    *mlbgameday.WatchOptions
+}
+
+func (s *daySource) watch(ctx context.Context) <-chan mlbgameday.Event {
+	return mlbgameday.NewScoreboardWatcher(s.gameday, s.opts).Watch(ctx)
+}
+
+func (s *daySource) snapshot() (interface{}, error) {
+	return s.gameday.Scoreboard()
+}
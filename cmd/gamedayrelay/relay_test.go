@@ -0,0 +1,474 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ericdreeves/mlbgameday"
+)
+
+// fakeSource is a source whose events are sent by the test.
+type fakeSource struct {
+	events  chan mlbgameday.Event
+	stopped chan struct{}
+
+	mu        sync.Mutex
+	watches   int
+	snapshots int
+}
+
+func newFakeSource() *fakeSource {
+	return &fakeSource{
+		events:  make(chan mlbgameday.Event),
+		stopped: make(chan struct{}),
+	}
+}
+
+func (s *fakeSource) watch(ctx context.Context) <-chan mlbgameday.Event {
+	s.mu.Lock()
+	s.watches++
+	s.mu.Unlock()
+
+	out := make(chan mlbgameday.Event)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case e, ok := <-s.events:
+				if !ok {
+					return
+				}
+				out <- e
+			case <-ctx.Done():
+				close(s.stopped)
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func (s *fakeSource) snapshot() (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snapshots++
+	return map[string]int{"snapshot": s.snapshots}, nil
+}
+
+func (s *fakeSource) counts() (int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.watches, s.snapshots
+}
+
+// setupRelay returns a relay server whose topics are fake sources.
+func setupRelay(t *testing.T) (*httptest.Server, *Hub, func(string) *fakeSource) {
+	var mu sync.Mutex
+	sources := make(map[string]*fakeSource)
+	get := func(key string) *fakeSource {
+		mu.Lock()
+		defer mu.Unlock()
+		if sources[key] == nil {
+			sources[key] = newFakeSource()
+		}
+		return sources[key]
+	}
+
+	hub := NewHub(func(key string) (source, error) {
+		return get(key), nil
+	})
+	return httptest.NewServer(NewServer(hub)), hub, get
+}
+
+type sseMessage struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// sseClient connects to an SSE endpoint.
+func sseClient(t *testing.T, url, lastEventID string) (*bufio.Reader, io.Closer) {
+	req, _ := http.NewRequest("GET", url, nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET %v returned error: %v", url, err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("GET %v returned %v", url, resp.Status)
+	}
+	if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("Content-Type is %q, want text/event-stream", got)
+	}
+	return bufio.NewReader(resp.Body), resp.Body
+}
+
+func readSSE(t *testing.T, r *bufio.Reader) sseMessage {
+	var m sseMessage
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Could not read SSE message: %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			return m
+		case strings.HasPrefix(line, "id: "):
+			m.ID = line[4:]
+		case strings.HasPrefix(line, "event: "):
+			m.Event = line[7:]
+		case strings.HasPrefix(line, "data: "):
+			m.Data = line[6:]
+		}
+	}
+}
+
+func runScored(runs int) mlbgameday.Event {
+	return mlbgameday.Event{
+		Type: mlbgameday.RunScored, GID: "2016_09_05_kcamlb_minmlb_1", Runs: runs,
+	}
+}
+
+func TestSSE(t *testing.T) {
+	server, _, sources := setupRelay(t)
+	defer server.Close()
+
+	url := server.URL + "/events/game/2016_09_05_kcamlb_minmlb_1"
+	r1, c1 := sseClient(t, url, "")
+	defer c1.Close()
+	r2, c2 := sseClient(t, url, "")
+	defer c2.Close()
+
+	snap := readSSE(t, r1)
+	epoch := strings.TrimSuffix(snap.ID, "-0")
+	want := sseMessage{epoch + "-0", "snapshot", `{"snapshot":1}`}
+	if snap != want || epoch == snap.ID {
+		t.Errorf("Initial message is %v, want a snapshot with id {epoch}-0", snap)
+	}
+	if got := readSSE(t, r2); got != want {
+		t.Errorf("Initial message is %v, want %v", got, want)
+	}
+
+	src := sources("game/2016_09_05_kcamlb_minmlb_1")
+	src.events <- runScored(2)
+
+	for _, r := range []*bufio.Reader{r1, r2} {
+		got := readSSE(t, r)
+		if got.ID != epoch+"-1" || got.Event != "RunScored" ||
+			!strings.Contains(got.Data, `"runs":2`) {
+			t.Errorf("Message is %v, want RunScored with id %v-1", got, epoch)
+		}
+	}
+
+	if watches, snapshots := src.counts(); watches != 1 || snapshots != 1 {
+		t.Errorf("Source was watched %v times with %v snapshots, want 1 and 1",
+			watches, snapshots)
+	}
+}
+
+func TestSSEResume(t *testing.T) {
+	server, hub, sources := setupRelay(t)
+	defer server.Close()
+	hub.history = 2
+
+	url := server.URL + "/events/day/2016-09-05"
+	r, c := sseClient(t, url, "")
+	defer c.Close()
+	ids := []string{readSSE(t, r).ID}
+
+	src := sources("day/2016/09/05")
+	for i := 1; i <= 3; i++ {
+		src.events <- runScored(i)
+		ids = append(ids, readSSE(t, r).ID)
+	}
+
+	resumed, c2 := sseClient(t, url, ids[1])
+	defer c2.Close()
+	for _, id := range ids[2:] {
+		if got := readSSE(t, resumed); got.ID != id || got.Event != "RunScored" {
+			t.Errorf("Resumed message is %v, want RunScored with id %v", got, id)
+		}
+	}
+
+	// Message 1 is no longer kept, so a client that missed it is sent a new
+	// snapshot.
+	stale, c3 := sseClient(t, url+"?last_event_id="+ids[0], "")
+	defer c3.Close()
+	want := sseMessage{ids[3], "snapshot", `{"snapshot":2}`}
+	if got := readSSE(t, stale); got != want {
+		t.Errorf("Message is %v, want %v", got, want)
+	}
+
+	// IDs of another instance of the topic are not resumed from, even if
+	// their sequence numbers are still kept.
+	other, c4 := sseClient(t, url, "0-2")
+	defer c4.Close()
+	if got := readSSE(t, other); got != want {
+		t.Errorf("Message is %v, want %v", got, want)
+	}
+}
+
+func TestSSETopicEnd(t *testing.T) {
+	server, _, sources := setupRelay(t)
+	defer server.Close()
+
+	r, c := sseClient(t, server.URL+"/events/game/448916", "")
+	defer c.Close()
+	readSSE(t, r)
+
+	close(sources("game/448916").events)
+	if got := readSSE(t, r); got.Event != "end" {
+		t.Errorf("Message is %v, want end", got)
+	}
+	if _, err := r.ReadString('\n'); err != io.EOF {
+		t.Errorf("Stream returned %v after the topic ended, want EOF", err)
+	}
+
+	// Clients that reconnect are told not to.
+	resp, err := http.Get(server.URL + "/events/game/448916")
+	if err != nil {
+		t.Fatalf("GET returned error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("GET after the topic ended returned %v, want 204", resp.Status)
+	}
+}
+
+func TestTopicLinger(t *testing.T) {
+	server, hub, sources := setupRelay(t)
+	defer server.Close()
+	hub.linger = 0
+
+	r, c := sseClient(t, server.URL+"/events/game/448916", "")
+	readSSE(t, r)
+	c.Close()
+
+	select {
+	case <-sources("game/448916").stopped:
+	case <-time.After(time.Second):
+		t.Fatalf("Source was not stopped after its last client left")
+	}
+}
+
+func TestSlowSource(t *testing.T) {
+	release := make(chan struct{})
+	hub := NewHub(func(key string) (source, error) {
+		if key == "game/slow" {
+			<-release
+		}
+		return newFakeSource(), nil
+	})
+
+	slow := make(chan error)
+	go func() {
+		_, _, unsubscribe, err := hub.subscribe("game/slow", "")
+		if err == nil {
+			unsubscribe()
+		}
+		slow <- err
+	}()
+
+	// A topic whose source is slow to start does not hold up other topics.
+	done := make(chan error)
+	go func() {
+		_, _, unsubscribe, err := hub.subscribe("game/448916", "")
+		if err == nil {
+			unsubscribe()
+		}
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("subscribe returned error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("subscribe waited for another topic's source")
+	}
+
+	close(release)
+	if err := <-slow; err != nil {
+		t.Errorf("subscribe returned error: %v", err)
+	}
+}
+
+func TestBadRequests(t *testing.T) {
+	server, _, _ := setupRelay(t)
+	defer server.Close()
+
+	var testCases = []struct {
+		Method, Path string
+		Want         int
+	}{
+		{"GET", "/events/team/kc", 404},
+		{"GET", "/events/game/", 404},
+		{"GET", "/events/day/2016-13-45", 404},
+		{"POST", "/events/game/448916", 405},
+		{"GET", "/ws/game/448916", 400},
+	}
+
+	for _, tc := range testCases {
+		req, _ := http.NewRequest(tc.Method, server.URL+tc.Path, nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%v %v returned error: %v", tc.Method, tc.Path, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tc.Want {
+			t.Errorf("%v %v returned %v, want %v",
+				tc.Method, tc.Path, resp.StatusCode, tc.Want)
+		}
+	}
+}
+
+// wsClient performs a WebSocket handshake with the server.
+func wsClient(t *testing.T, server *httptest.Server, path string) (net.Conn, *bufio.Reader) {
+	conn, err := net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+	if err != nil {
+		t.Fatalf("Could not connect: %v", err)
+	}
+
+	fmt.Fprintf(conn, "GET %v HTTP/1.1\r\nHost: localhost\r\n"+
+		"Upgrade: websocket\r\nConnection: keep-alive, Upgrade\r\n"+
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n"+
+		"Sec-WebSocket-Version: 13\r\n\r\n", path)
+
+	r := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(r, nil)
+	if err != nil {
+		t.Fatalf("Could not read handshake response: %v", err)
+	}
+	if resp.StatusCode != 101 {
+		t.Fatalf("Handshake returned %v, want 101", resp.Status)
+	}
+	if got, want := resp.Header.Get("Sec-WebSocket-Accept"),
+		"s3pPLMBiTxaQ9kYGzzhZRbK+xOo="; got != want {
+		t.Errorf("Sec-WebSocket-Accept is %q, want %q", got, want)
+	}
+
+	return conn, r
+}
+
+// readWSFrame reads an unmasked frame sent by the server.
+func readWSFrame(t *testing.T, r *bufio.Reader) (byte, []byte) {
+	var header [2]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		t.Fatalf("Could not read frame: %v", err)
+	}
+	n := int(header[1] & 0x7f)
+	if n == 126 {
+		var ext [2]byte
+		io.ReadFull(r, ext[:])
+		n = int(binary.BigEndian.Uint16(ext[:]))
+	}
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		t.Fatalf("Could not read frame: %v", err)
+	}
+	return header[0] & 0x0f, payload
+}
+
+// writeWSFrame writes a masked frame as sent by a client.
+func writeWSFrame(conn net.Conn, opcode byte, payload []byte) {
+	mask := []byte{1, 2, 3, 4}
+	frame := []byte{0x80 | opcode, 0x80 | byte(len(payload))}
+	frame = append(frame, mask...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	conn.Write(frame)
+}
+
+func TestWebSocket(t *testing.T) {
+	server, _, sources := setupRelay(t)
+	defer server.Close()
+
+	conn, r := wsClient(t, server, "/ws/game/448916")
+	defer conn.Close()
+
+	var m struct {
+		ID    string
+		Event string
+		Data  json.RawMessage
+	}
+	op, payload := readWSFrame(t, r)
+	if err := json.Unmarshal(payload, &m); op != opText || err != nil ||
+		!strings.HasSuffix(m.ID, "-0") || m.Event != "snapshot" {
+		t.Errorf("Initial frame is %v %s, want a snapshot", op, payload)
+	}
+	epoch := strings.TrimSuffix(m.ID, "-0")
+
+	sources("game/448916").events <- runScored(1)
+	op, payload = readWSFrame(t, r)
+	if err := json.Unmarshal(payload, &m); op != opText || err != nil ||
+		m.ID != epoch+"-1" || m.Event != "RunScored" {
+		t.Errorf("Frame is %v %s, want RunScored with id %v-1", op, payload, epoch)
+	}
+
+	writeWSFrame(conn, opPing, []byte("hi"))
+	if op, payload := readWSFrame(t, r); op != opPong || string(payload) != "hi" {
+		t.Errorf("Ping was answered with %v %q, want a pong", op, payload)
+	}
+
+	writeWSFrame(conn, opClose, []byte{0x03, 0xe8})
+	if op, _ := readWSFrame(t, r); op != opClose {
+		t.Errorf("Close was answered with %v, want a close frame", op)
+	}
+}
+
+func TestWebSocketResume(t *testing.T) {
+	server, _, sources := setupRelay(t)
+	defer server.Close()
+
+	conn, r := wsClient(t, server, "/ws/game/448916")
+	defer conn.Close()
+	var m struct{ ID string }
+	_, payload := readWSFrame(t, r)
+	json.Unmarshal(payload, &m)
+	epoch := strings.TrimSuffix(m.ID, "-0")
+
+	src := sources("game/448916")
+	src.events <- runScored(1)
+	src.events <- runScored(2)
+	readWSFrame(t, r)
+	readWSFrame(t, r)
+
+	resumed, r2 := wsClient(t, server, "/ws/game/448916?last_event_id="+epoch+"-1")
+	defer resumed.Close()
+	want := fmt.Sprintf(`{"id":"%v-2","event":"RunScored"`, epoch)
+	if _, payload := readWSFrame(t, r2); !strings.HasPrefix(string(payload), want) {
+		t.Errorf("Resumed frame is %s, want RunScored with id %v-2", payload, epoch)
+	}
+}
+
+func TestTopicKey(t *testing.T) {
+	var testCases = []struct {
+		Path, Want string
+	}{
+		{"game/448916", "game/448916"},
+		{"day/2016-09-05", "day/2016/09/05"},
+		{"day/2016/09/05", "day/2016/09/05"},
+	}
+
+	for _, tc := range testCases {
+		got, err := topicKey(tc.Path)
+		if err != nil || got != tc.Want {
+			t.Errorf("topicKey(%q) == %q, %v, want %q", tc.Path, got, err, tc.Want)
+		}
+	}
+}
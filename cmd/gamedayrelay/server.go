@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ericdreeves/mlbgameday"
+)
+
+// heartbeat is the interval at which idle connections are kept alive.
+var heartbeat = 15 * time.Second
+
+// Server serves the topics of a Hub over Server-Sent Events and WebSocket:
+//
+//	/events/game/{gid}   SSE stream of a game's events
+//	/events/day/{date}   SSE stream of the events of every game on a day
+//	/ws/game/{gid}       WebSocket stream of a game's events
+//	/ws/day/{date}       WebSocket stream of the events of every game on a day
+//
+// Dates are formatted as YYYY-MM-DD or YYYY/MM/DD. Once a topic ends, its
+// clients are sent an "end" message, and SSE requests for it are answered
+// with 204 No Content so that EventSource clients stop reconnecting.
+type Server struct {
+	hub *Hub
+	mux *http.ServeMux
+}
+
+// NewServer returns a new Server for the topics of the provided Hub.
+func NewServer(hub *Hub) *Server {
+	s := &Server{hub: hub, mux: http.NewServeMux()}
+	s.mux.HandleFunc("/events/", func(w http.ResponseWriter, r *http.Request) {
+		s.serve(w, r, "/events/", s.serveSSE)
+	})
+	s.mux.HandleFunc("/ws/", func(w http.ResponseWriter, r *http.Request) {
+		s.serve(w, r, "/ws/", s.serveWebSocket)
+	})
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// serve resolves the topic of the request and serves it with the provided
+// handler.
+func (s *Server) serve(w http.ResponseWriter, r *http.Request, prefix string, serve func(http.ResponseWriter, *http.Request, string)) {
+	if r.Method != "GET" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	key, err := topicKey(strings.TrimPrefix(r.URL.Path, prefix))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	serve(w, r, key)
+}
+
+// topicKey returns the key of the topic at the provided path, which is
+// "game/{gid}" or "day/{date}". Keys of days are normalized to
+// "day/YYYY/MM/DD".
+func topicKey(path string) (string, error) {
+	toks := strings.SplitN(path, "/", 2)
+	if len(toks) != 2 || toks[1] == "" {
+		return "", fmt.Errorf("Unknown topic %v", path)
+	}
+
+	switch toks[0] {
+	case "game":
+		return path, nil
+	case "day":
+		d, err := mlbgameday.ParseGameDate(toks[1])
+		if err != nil {
+			return "", err
+		}
+		return "day/" + d.String(), nil
+	}
+
+	return "", fmt.Errorf("Unknown topic %v", path)
+}
+
+// lastEventID returns the ID of the last message received by a resuming
+// client, from the Last-Event-ID header or, for clients that cannot set it,
+// the last_event_id query parameter.
+func lastEventID(r *http.Request) string {
+	if id := r.Header.Get("Last-Event-ID"); id != "" {
+		return id
+	}
+	return r.URL.Query().Get("last_event_id")
+}
+
+// serveSSE streams the topic as Server-Sent Events.
+func (s *Server) serveSSE(w http.ResponseWriter, r *http.Request, key string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	initial, ch, unsubscribe, err := s.hub.subscribe(key, lastEventID(r))
+	if err == errTopicEnded {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	for _, m := range initial {
+		writeSSE(w, m)
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case m, ok := <-ch:
+			if !ok {
+				return
+			}
+			writeSSE(w, m)
+		case <-ticker.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		case <-r.Context().Done():
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// writeSSE writes a message in the Server-Sent Events format.
+func writeSSE(w http.ResponseWriter, m message) {
+	fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", m.ID, m.Event, m.Data)
+}
+
+// serveWebSocket streams the topic over a WebSocket. Each message is sent as
+// a text frame holding a JSON object with the message's id, event and data.
+func (s *Server) serveWebSocket(w http.ResponseWriter, r *http.Request, key string) {
+	conn, err := upgrade(w, r)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	initial, ch, unsubscribe, err := s.hub.subscribe(key, lastEventID(r))
+	if err == errTopicEnded {
+		conn.close(closeNormal, "")
+		return
+	}
+	if err != nil {
+		conn.close(closeInternalError, err.Error())
+		return
+	}
+	defer unsubscribe()
+
+	for _, m := range initial {
+		if err := conn.writeMessage(m); err != nil {
+			return
+		}
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		conn.readUntilClose()
+		close(closed)
+	}()
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case m, ok := <-ch:
+			if !ok {
+				conn.close(closeNormal, "")
+				return
+			}
+			if err := conn.writeMessage(m); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.writeFrame(opPing, nil); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// The minimal subset of the WebSocket protocol (RFC 6455) needed to stream
+// messages to browsers: the server handshake, unfragmented text frames and
+// the ping, pong and close control frames.
+
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// WebSocket frame opcodes.
+const (
+	opText  = 0x1
+	opClose = 0x8
+	opPing  = 0x9
+	opPong  = 0xa
+)
+
+// WebSocket close status codes.
+const (
+	closeNormal        = 1000
+	closeInternalError = 1011
+)
+
+// maxFrameSize is the largest frame accepted from a client. Clients only
+// send control frames, which are limited to 125 bytes.
+const maxFrameSize = 1 << 16
+
+// wsConn is a server-side WebSocket connection.
+type wsConn struct {
+	conn net.Conn
+	rw   *bufio.ReadWriter
+
+	// mu serializes writes, which are made both by the writer of messages
+	// and by the reader replying to pings.
+	mu sync.Mutex
+}
+
+// upgrade performs the WebSocket handshake for the request. It responds with
+// an error and returns it if the request is not a valid handshake.
+func upgrade(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if !headerContains(r.Header, "Connection", "upgrade") ||
+		!headerContains(r.Header, "Upgrade", "websocket") ||
+		r.Header.Get("Sec-WebSocket-Version") != "13" || key == "" {
+		http.Error(w, "Bad WebSocket handshake", http.StatusBadRequest)
+		return nil, errors.New("Bad WebSocket handshake")
+	}
+
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "WebSocket unsupported", http.StatusInternalServerError)
+		return nil, errors.New("WebSocket unsupported")
+	}
+	conn, rw, err := hj.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n\r\n")
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &wsConn{conn: conn, rw: rw}, nil
+}
+
+// acceptKey returns the Sec-WebSocket-Accept value for a handshake key.
+func acceptKey(key string) string {
+	h := sha1.New()
+	io.WriteString(h, key+websocketGUID)
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// headerContains returns whether the comma-separated values of the header
+// include the provided token, ignoring case.
+func headerContains(h http.Header, name, token string) bool {
+	for _, v := range h[http.CanonicalHeaderKey(name)] {
+		for _, t := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeMessage sends a message as a text frame.
+func (c *wsConn) writeMessage(m message) error {
+	data, err := json.Marshal(struct {
+		ID    string          `json:"id"`
+		Event string          `json:"event"`
+		Data  json.RawMessage `json:"data"`
+	}{m.ID, m.Event, m.Data})
+	if err != nil {
+		return err
+	}
+
+	return c.writeFrame(opText, data)
+}
+
+// writeFrame sends a single unfragmented frame. Frames sent by a server are
+// not masked.
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	header := []byte{0x80 | opcode, 0}
+	switch n := len(payload); {
+	case n < 126:
+		header[1] = byte(n)
+	case n <= 0xffff:
+		header[1] = 126
+		header = append(header, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(n))
+	default:
+		header[1] = 127
+		header = append(header, make([]byte, 8)...)
+		binary.BigEndian.PutUint64(header[2:], uint64(n))
+	}
+
+	c.rw.Write(header)
+	c.rw.Write(payload)
+	return c.rw.Flush()
+}
+
+// readFrame reads a single frame sent by the client and unmasks its payload.
+func (c *wsConn) readFrame() (byte, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.rw, header[:]); err != nil {
+		return 0, nil, err
+	}
+
+	opcode := header[0] & 0x0f
+	masked := header[1]&0x80 != 0
+	n := uint64(header[1] & 0x7f)
+	switch n {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.rw, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		n = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.rw, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		n = binary.BigEndian.Uint64(ext[:])
+	}
+	if !masked {
+		return 0, nil, errors.New("Client frame is not masked")
+	}
+	if n > maxFrameSize {
+		return 0, nil, errors.New("Client frame is too large")
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.rw, mask[:]); err != nil {
+		return 0, nil, err
+	}
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(c.rw, payload); err != nil {
+		return 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return opcode, payload, nil
+}
+
+// readUntilClose reads frames from the client, answering pings, until the
+// client closes the connection or a read fails.
+func (c *wsConn) readUntilClose() {
+	for {
+		opcode, payload, err := c.readFrame()
+		if err != nil {
+			return
+		}
+
+		switch opcode {
+		case opPing:
+			c.writeFrame(opPong, payload)
+		case opClose:
+			c.writeFrame(opClose, payload)
+			return
+		}
+	}
+}
+
+// close sends a close frame with the provided status code and reason. The
+// reason is truncated to fit in a control frame.
+func (c *wsConn) close(code int, reason string) error {
+	if len(reason) > 123 {
+		reason = reason[:123]
+	}
+	payload := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	payload = append(payload, reason...)
+
+	return c.writeFrame(opClose, payload)
+}
+
+// Close closes the underlying connection.
+func (c *wsConn) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,416 @@
+package mlbgameday
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ChangeKind identifies how an item differs between two snapshots.
+type ChangeKind int
+
+// Kinds of changes reported by DiffScoreboards and DiffAtBats.
+const (
+	Added    ChangeKind = iota // The item is only in the new snapshot
+	Removed                    // The item is only in the old snapshot
+	Modified                   // The item is in both snapshots but differs
+)
+
+var changeKindNames = []string{"Added", "Removed", "Modified"}
+
+// String returns the name of the change kind, e.g. "Modified".
+func (k ChangeKind) String() string {
+	if k < 0 || int(k) >= len(changeKindNames) {
+		return "Unknown"
+	}
+	return changeKindNames[k]
+}
+
+// FieldChange represents a field whose value differs between two snapshots.
+// Values are formatted with fmt.Sprint.
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// GameChange represents a game that differs between two scoreboards.
+type GameChange struct {
+	Kind ChangeKind
+	GID  string
+
+	// Old and New are the game in the old and new scoreboards. Old is nil for
+	// added games and New is nil for removed games.
+	Old *Game
+	New *Game
+
+	// Fields lists the fields of a modified game that changed.
+	Fields []FieldChange
+}
+
+// DiffScoreboards returns the games that differ between two scoreboards,
+// matched by GID. Changes are listed in the order of the games on the new
+// scoreboard, followed by games removed from the old scoreboard. Either
+// scoreboard may be nil.
+func DiffScoreboards(old, new *Scoreboard) []GameChange {
+	var oldGames, newGames []Game
+	if old != nil {
+		oldGames = old.Games
+	}
+	if new != nil {
+		newGames = new.Games
+	}
+
+	prev := make(map[string]*Game)
+	for i := range oldGames {
+		prev[oldGames[i].GID] = &oldGames[i]
+	}
+
+	var changes []GameChange
+	seen := make(map[string]bool)
+	for i := range newGames {
+		g := &newGames[i]
+		seen[g.GID] = true
+
+		o, ok := prev[g.GID]
+		if !ok {
+			changes = append(changes, GameChange{Added, g.GID, nil, g, nil})
+			continue
+		}
+		if fields := diffFields(*o, *g); len(fields) > 0 {
+			changes = append(changes, GameChange{Modified, g.GID, o, g, fields})
+		}
+	}
+
+	for i := range oldGames {
+		if g := &oldGames[i]; !seen[g.GID] {
+			changes = append(changes, GameChange{Removed, g.GID, g, nil, nil})
+		}
+	}
+
+	return changes
+}
+
+// AtBatChange represents an at-bat that differs between two snapshots of a
+// game's at-bats.
+type AtBatChange struct {
+	Kind ChangeKind
+
+	// Inning and TopInning identify the half inning of the at-bat in the new
+	// snapshot, or in the old snapshot for removed at-bats.
+	Inning    int
+	TopInning string
+
+	// Number is the number of the at-bat within the game.
+	Number int
+
+	// Old and New are the at-bat in the old and new snapshots. Old is nil for
+	// added at-bats and New is nil for removed at-bats.
+	Old *AtBat
+	New *AtBat
+
+	// Fields lists the fields of a modified at-bat that changed, other than
+	// its pitches and runners.
+	Fields []FieldChange
+
+	// Pitches and Runners list the pitches and runner movements of a
+	// modified at-bat that changed.
+	Pitches []PitchChange
+	Runners []RunnerChange
+
+	// Actions lists the actions that changed during the at-bat. See
+	// DiffAtBats.
+	Actions []ActionChange
+}
+
+// PitchChange represents a pitch that differs between two snapshots of an
+// at-bat.
+type PitchChange struct {
+	Kind ChangeKind
+
+	// Index is the position of the pitch within the at-bat.
+	Index int
+
+	Old *Pitch
+	New *Pitch
+
+	// Fields lists the fields of a modified pitch that changed.
+	Fields []FieldChange
+}
+
+// RunnerChange represents a runner movement that differs between two
+// snapshots of an at-bat.
+type RunnerChange struct {
+	Kind ChangeKind
+
+	// Index is the position of the movement within the at-bat.
+	Index int
+
+	Old *Runner
+	New *Runner
+
+	// Fields lists the fields of a modified movement that changed.
+	Fields []FieldChange
+}
+
+// ActionChange represents an action, such as a stolen base or substitution,
+// that differs between two snapshots of a game's at-bats.
+type ActionChange struct {
+	Kind ChangeKind
+
+	// EventNum identifies the action within the game.
+	EventNum int
+
+	Old *Action
+	New *Action
+
+	// Fields lists the fields of a modified action that changed.
+	Fields []FieldChange
+}
+
+// Corrected returns whether the change revises the outcome of an at-bat that
+// had already ended, as when an official scorer changes a hit to an error or
+// a run from earned to unearned.
+func (c *AtBatChange) Corrected() bool {
+	if c.Kind != Modified || c.Old.Event == "" {
+		return false
+	}
+	for _, f := range c.Fields {
+		if f.Field == "Event" {
+			return true
+		}
+	}
+	for _, r := range c.Runners {
+		if r.Kind != Modified {
+			continue
+		}
+		for _, f := range r.Fields {
+			if f.Field == "RBI" || f.Field == "Earned" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// DiffAtBats returns the at-bats that differ between two snapshots of a
+// game's at-bats, matched by at-bat number. Pitches and runner movements are
+// matched by their position within the at-bat. Actions are matched by event
+// number and reported with the at-bat during which they occurred: the first
+// at-bat of their half inning to end after them, or the last at-bat of the
+// half inning for actions after it. Actions in a half inning without at-bats
+// are not reported. Changes are listed in the order of the at-bats in the new
+// snapshot, followed by at-bats removed from the old snapshot. Either
+// snapshot may be nil.
+func DiffAtBats(old, new *AtBats) []AtBatChange {
+	oldAtBats := indexAtBats(old)
+	newAtBats := indexAtBats(new)
+	actions := diffActions(oldAtBats, newAtBats)
+
+	prev := make(map[int]*indexedAtBat)
+	for i := range oldAtBats {
+		prev[oldAtBats[i].Number] = &oldAtBats[i]
+	}
+
+	var changes []AtBatChange
+	seen := make(map[int]bool)
+	for _, n := range newAtBats {
+		seen[n.Number] = true
+
+		o, ok := prev[n.Number]
+		if !ok {
+			changes = append(changes, AtBatChange{
+				Kind: Added, Inning: n.inning, TopInning: n.top,
+				Number: n.Number, New: n.AtBat, Actions: actions[n.Number],
+			})
+			continue
+		}
+
+		c := AtBatChange{
+			Kind: Modified, Inning: n.inning, TopInning: n.top,
+			Number: n.Number, Old: o.AtBat, New: n.AtBat,
+			Fields:  diffFields(*o.AtBat, *n.AtBat),
+			Pitches: diffPitches(o.Pitches, n.Pitches),
+			Runners: diffRunners(o.Runners, n.Runners),
+			Actions: actions[n.Number],
+		}
+		if o.inning != n.inning || o.top != n.top {
+			c.Fields = append(c.Fields,
+				FieldChange{"Inning", fmt.Sprint(o.inning), fmt.Sprint(n.inning)},
+				FieldChange{"TopInning", o.top, n.top})
+		}
+		if len(c.Fields) > 0 || len(c.Pitches) > 0 || len(c.Runners) > 0 ||
+			len(c.Actions) > 0 {
+			changes = append(changes, c)
+		}
+	}
+
+	for _, o := range oldAtBats {
+		if !seen[o.Number] {
+			changes = append(changes, AtBatChange{
+				Kind: Removed, Inning: o.inning, TopInning: o.top,
+				Number: o.Number, Old: o.AtBat, Actions: actions[o.Number],
+			})
+		}
+	}
+
+	return changes
+}
+
+// indexedAtBat is an at-bat along with the half inning in which it occurred
+// and the actions that occurred during it.
+type indexedAtBat struct {
+	*AtBat
+	inning  int
+	top     string
+	actions []*Action
+}
+
+// indexAtBats returns every at-bat in abs in order.
+func indexAtBats(abs *AtBats) []indexedAtBat {
+	if abs == nil {
+		return nil
+	}
+
+	var all []indexedAtBat
+	for i := range abs.Innings {
+		inning := &abs.Innings[i]
+		all = appendHalfInning(all, inning.Top, inning.TopActions, inning.Number, "Y")
+		all = appendHalfInning(all, inning.Bottom, inning.BottomActions, inning.Number, "N")
+	}
+
+	return all
+}
+
+// appendHalfInning appends the at-bats of a half inning to all, each with the
+// actions that occurred during it.
+func appendHalfInning(all []indexedAtBat, abs []AtBat, actions []Action, inning int, top string) []indexedAtBat {
+	first := len(all)
+	for i := range abs {
+		all = append(all, indexedAtBat{&abs[i], inning, top, nil})
+	}
+	if first == len(all) {
+		return all
+	}
+
+	j := first
+	for i := range actions {
+		for j < len(all)-1 && all[j].EventNum <= actions[i].EventNum {
+			j++
+		}
+		all[j].actions = append(all[j].actions, &actions[i])
+	}
+
+	return all
+}
+
+// diffActions returns the actions that differ between two snapshots, matched
+// by event number, by the number of the at-bat during which they occurred in
+// the new snapshot, or in the old snapshot for removed actions.
+func diffActions(old, new []indexedAtBat) map[int][]ActionChange {
+	prev := make(map[int]*Action)
+	for _, ab := range old {
+		for _, a := range ab.actions {
+			prev[a.EventNum] = a
+		}
+	}
+
+	changes := make(map[int][]ActionChange)
+	seen := make(map[int]bool)
+	for _, ab := range new {
+		for _, a := range ab.actions {
+			seen[a.EventNum] = true
+			o, ok := prev[a.EventNum]
+			if !ok {
+				changes[ab.Number] = append(changes[ab.Number],
+					ActionChange{Added, a.EventNum, nil, a, nil})
+				continue
+			}
+			if fields := diffFields(*o, *a); len(fields) > 0 {
+				changes[ab.Number] = append(changes[ab.Number],
+					ActionChange{Modified, a.EventNum, o, a, fields})
+			}
+		}
+	}
+
+	for _, ab := range old {
+		for _, a := range ab.actions {
+			if !seen[a.EventNum] {
+				changes[ab.Number] = append(changes[ab.Number],
+					ActionChange{Removed, a.EventNum, a, nil, nil})
+			}
+		}
+	}
+
+	return changes
+}
+
+// diffRunners returns the runner movements that differ between two snapshots
+// of an at-bat.
+func diffRunners(old, new []Runner) []RunnerChange {
+	var changes []RunnerChange
+	for i := 0; i < len(old) || i < len(new); i++ {
+		switch {
+		case i >= len(old):
+			changes = append(changes, RunnerChange{Added, i, nil, &new[i], nil})
+		case i >= len(new):
+			changes = append(changes, RunnerChange{Removed, i, &old[i], nil, nil})
+		default:
+			if fields := diffFields(old[i], new[i]); len(fields) > 0 {
+				changes = append(changes,
+					RunnerChange{Modified, i, &old[i], &new[i], fields})
+			}
+		}
+	}
+
+	return changes
+}
+
+// diffPitches returns the pitches that differ between two snapshots of an
+// at-bat.
+func diffPitches(old, new []Pitch) []PitchChange {
+	var changes []PitchChange
+	for i := 0; i < len(old) || i < len(new); i++ {
+		switch {
+		case i >= len(old):
+			changes = append(changes, PitchChange{Added, i, nil, &new[i], nil})
+		case i >= len(new):
+			changes = append(changes, PitchChange{Removed, i, &old[i], nil, nil})
+		default:
+			if fields := diffFields(old[i], new[i]); len(fields) > 0 {
+				changes = append(changes,
+					PitchChange{Modified, i, &old[i], &new[i], fields})
+			}
+		}
+	}
+
+	return changes
+}
+
+// diffFields returns the exported fields of two structs of the same type
+// whose values differ. Fields of embedded structs are compared as fields of
+// the outer struct. Slices, such as the pitches of an at-bat, are skipped.
+func diffFields(old, new interface{}) []FieldChange {
+	ov, nv := reflect.ValueOf(old), reflect.ValueOf(new)
+	t := ov.Type()
+
+	var fields []FieldChange
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" || f.Type.Kind() == reflect.Slice {
+			continue
+		}
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			fields = append(fields,
+				diffFields(ov.Field(i).Interface(), nv.Field(i).Interface())...)
+			continue
+		}
+
+		o, n := ov.Field(i).Interface(), nv.Field(i).Interface()
+		if !reflect.DeepEqual(o, n) {
+			fields = append(fields,
+				FieldChange{t.Field(i).Name, fmt.Sprint(o), fmt.Sprint(n)})
+		}
+	}
+
+	return fields
+}
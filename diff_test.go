@@ -0,0 +1,235 @@
+package mlbgameday
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func readMock(t *testing.T, file string, v interface{}) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal("Could not read data file")
+	}
+	if err := xml.Unmarshal(data, v); err != nil {
+		t.Fatalf("Could not decode data file: %v", err)
+	}
+}
+
+func TestDiffScoreboards(t *testing.T) {
+	before, after := new(Scoreboard), new(Scoreboard)
+	readMock(t, "./mock/miniscoreboard.xml", before)
+	readMock(t, "./mock/miniscoreboard.xml", after)
+
+	if got := DiffScoreboards(before, after); len(got) != 0 {
+		t.Errorf("DiffScoreboards returned %v for identical scoreboards", got)
+	}
+
+	after.Games[0].HomeTeamRuns = 3
+	after.Games[0].Outs = 1
+	removed := after.Games[1]
+	added := Game{GID: "2016_09_05_kcamlb_minmlb_1"}
+	after.Games[1] = added
+
+	got := DiffScoreboards(before, after)
+	want := []GameChange{
+		{
+			Modified, before.Games[0].GID, &before.Games[0], &after.Games[0],
+			[]FieldChange{{"Outs", "0", "1"}, {"HomeTeamRuns", "0", "3"}},
+		},
+		{Added, added.GID, nil, &after.Games[1], nil},
+		{Removed, removed.GID, &before.Games[1], nil, nil},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffScoreboards returned %+v, want %+v", got, want)
+	}
+}
+
+func TestDiffScoreboardsNil(t *testing.T) {
+	sb := new(Scoreboard)
+	readMock(t, "./mock/miniscoreboard.xml", sb)
+
+	got := DiffScoreboards(nil, sb)
+	if len(got) != 2 || got[0].Kind != Added || got[1].Kind != Added {
+		t.Errorf("DiffScoreboards(nil, sb) returned %v, want 2 added games", got)
+	}
+
+	got = DiffScoreboards(sb, nil)
+	if len(got) != 2 || got[0].Kind != Removed || got[1].Kind != Removed {
+		t.Errorf("DiffScoreboards(sb, nil) returned %v, want 2 removed games", got)
+	}
+}
+
+func TestDiffAtBats(t *testing.T) {
+	before, after := new(AtBats), new(AtBats)
+	readMock(t, "./mock/inning_all.xml", before)
+	readMock(t, "./mock/inning_all.xml", after)
+
+	if got := DiffAtBats(before, after); len(got) != 0 {
+		t.Errorf("DiffAtBats returned %v for identical at-bats", got)
+	}
+
+	// A scorer's correction of the first at-bat and its final pitch.
+	first := &after.Innings[0].Top[0]
+	first.Event = "Field Error"
+	last := len(first.Pitches) - 1
+	first.Pitches[last].Des = "In play, run(s)"
+
+	// The last at-bat is removed and a new one is added.
+	inning := &after.Innings[len(after.Innings)-1]
+	half := &inning.Bottom
+	if len(*half) == 0 {
+		half = &inning.Top
+	}
+	removed := (*half)[len(*half)-1]
+	added := AtBat{AtBatSummary: AtBatSummary{Number: 1000, Event: "Walk"}}
+	(*half)[len(*half)-1] = added
+
+	got := DiffAtBats(before, after)
+	if len(got) != 3 {
+		t.Fatalf("DiffAtBats returned %v changes, want 3", len(got))
+	}
+
+	corrected := got[0]
+	wantFields := []FieldChange{{"Event", "Single", "Field Error"}}
+	if corrected.Kind != Modified || corrected.Number != 1 ||
+		!reflect.DeepEqual(corrected.Fields, wantFields) || !corrected.Corrected() {
+		t.Errorf("DiffAtBats returned %+v, want a correction of at-bat 1", corrected)
+	}
+	wantPitches := []PitchChange{{
+		Modified, last, &before.Innings[0].Top[0].Pitches[last], &first.Pitches[last],
+		[]FieldChange{{"Des", "In play, no out", "In play, run(s)"}},
+	}}
+	if !reflect.DeepEqual(corrected.Pitches, wantPitches) {
+		t.Errorf("AtBatChange.Pitches is %+v, want %+v",
+			corrected.Pitches, wantPitches)
+	}
+
+	if got[1].Kind != Added || got[1].Number != 1000 || got[1].Corrected() {
+		t.Errorf("DiffAtBats returned %+v, want at-bat 1000 added", got[1])
+	}
+	if got[2].Kind != Removed || got[2].Number != removed.Number {
+		t.Errorf("DiffAtBats returned %+v, want at-bat %v removed",
+			got[2], removed.Number)
+	}
+}
+
+func TestDiffAtBatsDetails(t *testing.T) {
+	before, after := new(AtBats), new(AtBats)
+	readMock(t, "./mock/inning_all.xml", before)
+	readMock(t, "./mock/inning_all.xml", after)
+
+	var walk *AtBat
+	var half *[]Action
+	for i := range after.Innings {
+		inning := &after.Innings[i]
+		for j := range inning.Top {
+			if inning.Top[j].Number == 40 {
+				walk, half = &inning.Top[j], &inning.TopActions
+			}
+		}
+	}
+	if walk == nil {
+		t.Fatal("Could not find at-bat 40")
+	}
+
+	// A scorer's revision of the description of the walk during which Dyson
+	// stole second and of the steal itself, and a correction of the home run
+	// that led off the bottom of the first from earned to unearned.
+	walk.Des = "Eric Hosmer walks intentionally.  "
+	walk.Stand = "R"
+	for i := range *half {
+		if (*half)[i].EventNum == 319 {
+			(*half)[i].Event = "Stolen Base 3B"
+		}
+	}
+	runner := &after.Innings[0].Bottom[0].Runners[0]
+	runner.Earned = ""
+
+	got := DiffAtBats(before, after)
+	if len(got) != 2 {
+		t.Fatalf("DiffAtBats returned %v changes, want 2", len(got))
+	}
+
+	corrected := got[0]
+	if corrected.Number != after.Innings[0].Bottom[0].Number || len(corrected.Fields) != 0 ||
+		!corrected.Corrected() {
+		t.Errorf("DiffAtBats returned %+v, want a correction of the home run", corrected)
+	}
+	wantRunners := []RunnerChange{{
+		Modified, 0, &before.Innings[0].Bottom[0].Runners[0], runner,
+		[]FieldChange{{"Earned", "T", ""}},
+	}}
+	if !reflect.DeepEqual(corrected.Runners, wantRunners) {
+		t.Errorf("AtBatChange.Runners is %+v, want %+v", corrected.Runners, wantRunners)
+	}
+
+	revised := got[1]
+	wantFields := []FieldChange{
+		{"Stand", "L", "R"},
+		{"Des", "Eric Hosmer walks.  ", "Eric Hosmer walks intentionally.  "},
+	}
+	if revised.Number != 40 || !reflect.DeepEqual(revised.Fields, wantFields) ||
+		revised.Corrected() {
+		t.Errorf("DiffAtBats returned %+v, want at-bat 40 with fields %v", revised, wantFields)
+	}
+	if len(revised.Actions) != 1 || revised.Actions[0].Kind != Modified ||
+		revised.Actions[0].EventNum != 319 ||
+		!reflect.DeepEqual(revised.Actions[0].Fields,
+			[]FieldChange{{"Event", "Stolen Base 2B", "Stolen Base 3B"}}) {
+		t.Errorf("AtBatChange.Actions is %+v, want the stolen base modified", revised.Actions)
+	}
+
+	// An action published after the last at-bat of a half inning is reported
+	// with that at-bat, and stays unchanged when the next at-bat appears.
+	ab := AtBat{AtBatSummary: AtBatSummary{Number: 1}, EventNum: 2}
+	change := Action{Event: "Pitching Substitution", EventNum: 3}
+	old := &AtBats{[]AtBatInning{{Number: 1, Top: []AtBat{ab}}}}
+	mid := &AtBats{[]AtBatInning{{Number: 1, Top: []AtBat{ab},
+		TopActions: []Action{change}}}}
+	next := AtBat{AtBatSummary: AtBatSummary{Number: 2}, EventNum: 4}
+	cur := &AtBats{[]AtBatInning{{Number: 1, Top: []AtBat{ab, next},
+		TopActions: []Action{change}}}}
+
+	got = DiffAtBats(old, mid)
+	if len(got) != 1 || got[0].Number != 1 || len(got[0].Actions) != 1 ||
+		got[0].Actions[0].Kind != Added {
+		t.Errorf("DiffAtBats returned %+v, want the pitching change added to at-bat 1", got)
+	}
+	got = DiffAtBats(mid, cur)
+	if len(got) != 1 || got[0].Kind != Added || got[0].Number != 2 || len(got[0].Actions) != 0 {
+		t.Errorf("DiffAtBats returned %+v, want only at-bat 2 added", got)
+	}
+}
+
+func TestDiffAtBatsNewPitch(t *testing.T) {
+	ab := AtBat{
+		AtBatSummary: AtBatSummary{Number: 1},
+		Pitches:      []Pitch{{Des: "Ball", Type: "B"}},
+	}
+	before := &AtBats{[]AtBatInning{{Number: 1, Top: []AtBat{ab}}}}
+
+	ab.Pitches = append(ab.Pitches, Pitch{Des: "Called Strike", Type: "S"})
+	ab.Strikes = 1
+	after := &AtBats{[]AtBatInning{{Number: 1, Top: []AtBat{ab}}}}
+
+	got := DiffAtBats(before, after)
+	if len(got) != 1 || got[0].Corrected() {
+		t.Fatalf("DiffAtBats returned %+v, want 1 uncorrected change", got)
+	}
+	want := []PitchChange{{Added, 1, nil, &after.Innings[0].Top[0].Pitches[1], nil}}
+	if !reflect.DeepEqual(got[0].Pitches, want) {
+		t.Errorf("AtBatChange.Pitches is %+v, want %+v", got[0].Pitches, want)
+	}
+	wantFields := []FieldChange{{"Strikes", "0", "1"}}
+	if !reflect.DeepEqual(got[0].Fields, wantFields) {
+		t.Errorf("AtBatChange.Fields is %v, want %v", got[0].Fields, wantFields)
+	}
+}
+
+func TestChangeKindString(t *testing.T) {
+	if got := Modified.String(); got != "Modified" {
+		t.Errorf("Modified.String() == %q, want %q", got, "Modified")
+	}
+}
@@ -0,0 +1,175 @@
+package mlbgameday
+
+import "strings"
+
+// IsSwing returns whether the batter swung at the pitch, including bunt
+// attempts.
+func (p *Pitch) IsSwing() bool {
+	return p.IsWhiff() || p.IsInPlay() || strings.HasPrefix(p.Des, "Foul")
+}
+
+// IsWhiff returns whether the batter swung at the pitch and missed it.
+func (p *Pitch) IsWhiff() bool {
+	return strings.HasPrefix(p.Des, "Swinging") || p.Des == "Missed Bunt"
+}
+
+// IsInPlay returns whether the pitch was put in play.
+func (p *Pitch) IsInPlay() bool {
+	return p.Type == "X"
+}
+
+// InZone returns whether the pitch crossed the strike zone as classified by
+// the feed: its Zone is one of the nine parts of the zone, numbered 1 to 9,
+// rather than one of the four around it, numbered 11 to 14.
+func (p *Pitch) InZone() bool {
+	return p.Zone >= 1 && p.Zone <= 9
+}
+
+// Discipline tallies the swings and takes on a set of pitches, from which the
+// plate discipline rates of a batter or pitcher are computed. Locations are
+// those of the feed's Zone; see Pitch.InZone.
+type Discipline struct {
+	Pitches int
+
+	// Located is the number of pitches with a Zone, of which InZone crossed
+	// the strike zone.
+	Located int
+	InZone  int
+
+	// Swings is the number of swings, of which ZoneSwings were at pitches in
+	// the zone and Chases at pitches out of it. Contacts, and ZoneContacts,
+	// are the swings that did not miss.
+	Swings       int
+	ZoneSwings   int
+	Chases       int
+	Contacts     int
+	ZoneContacts int
+
+	CalledStrikes int
+}
+
+// Add adds a pitch to the tally.
+func (d *Discipline) Add(p *Pitch) {
+	d.Pitches++
+	located, in := p.Zone > 0, p.InZone()
+	if located {
+		d.Located++
+	}
+	if in {
+		d.InZone++
+	}
+
+	if !p.IsSwing() {
+		if p.Des == "Called Strike" {
+			d.CalledStrikes++
+		}
+		return
+	}
+	contact := !p.IsWhiff()
+	d.Swings++
+	if contact {
+		d.Contacts++
+	}
+	switch {
+	case in:
+		d.ZoneSwings++
+		if contact {
+			d.ZoneContacts++
+		}
+	case located:
+		d.Chases++
+	}
+}
+
+// Merge adds the tally of another set of pitches.
+func (d *Discipline) Merge(o Discipline) {
+	d.Pitches += o.Pitches
+	d.Located += o.Located
+	d.InZone += o.InZone
+	d.Swings += o.Swings
+	d.ZoneSwings += o.ZoneSwings
+	d.Chases += o.Chases
+	d.Contacts += o.Contacts
+	d.ZoneContacts += o.ZoneContacts
+	d.CalledStrikes += o.CalledStrikes
+}
+
+// ZoneRate returns the fraction of located pitches in the zone.
+func (d Discipline) ZoneRate() float64 {
+	return ratio(d.InZone, d.Located)
+}
+
+// SwingRate returns the fraction of pitches swung at.
+func (d Discipline) SwingRate() float64 {
+	return ratio(d.Swings, d.Pitches)
+}
+
+// ZoneSwingRate returns the fraction of pitches in the zone swung at.
+func (d Discipline) ZoneSwingRate() float64 {
+	return ratio(d.ZoneSwings, d.InZone)
+}
+
+// ChaseRate returns the fraction of located pitches out of the zone swung
+// at.
+func (d Discipline) ChaseRate() float64 {
+	return ratio(d.Chases, d.Located-d.InZone)
+}
+
+// ContactRate returns the fraction of swings that made contact.
+func (d Discipline) ContactRate() float64 {
+	return ratio(d.Contacts, d.Swings)
+}
+
+// ZoneContactRate returns the fraction of swings at pitches in the zone that
+// made contact.
+func (d Discipline) ZoneContactRate() float64 {
+	return ratio(d.ZoneContacts, d.ZoneSwings)
+}
+
+// WhiffRate returns the fraction of swings that missed.
+func (d Discipline) WhiffRate() float64 {
+	return ratio(d.Swings-d.Contacts, d.Swings)
+}
+
+// CalledStrikeRate returns the fraction of pitches called strikes.
+func (d Discipline) CalledStrikeRate() float64 {
+	return ratio(d.CalledStrikes, d.Pitches)
+}
+
+// Split tallies a set of plate appearances and the pitches thrown in them,
+// such as those of a batter or pitcher against one type of pitch. Pitches
+// are added with Add and the results of plate appearances with
+// AddPlateAppearance.
+type Split struct {
+	PlateAppearances int
+	Hits             int
+	HomeRuns         int
+	Walks            int
+	Strikeouts       int
+
+	Discipline
+}
+
+// AddPlateAppearance adds the result of a plate appearance.
+func (s *Split) AddPlateAppearance(ab *AtBat) {
+	s.PlateAppearances++
+	switch {
+	case ab.IsHit():
+		s.Hits++
+		if ab.Event == "Home Run" {
+			s.HomeRuns++
+		}
+	case ab.IsWalk():
+		s.Walks++
+	case ab.IsStrikeout():
+		s.Strikeouts++
+	}
+}
+
+// ratio returns n/d, or 0 if d is 0.
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
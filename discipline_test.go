@@ -0,0 +1,124 @@
+package mlbgameday
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDiscipline(t *testing.T) {
+	abs := new(AtBats)
+	readMock(t, "./mock/inning_all.xml", abs)
+
+	var d Discipline
+	WalkGameStates(abs, func(step GameStep) error {
+		if step.Kind == PitchStep {
+			d.Add(step.Pitch)
+		}
+		return nil
+	})
+
+	want := Discipline{
+		Pitches:       327,
+		Located:       327,
+		InZone:        129,
+		Swings:        156,
+		ZoneSwings:    92,
+		Chases:        64,
+		Contacts:      124,
+		ZoneContacts:  80,
+		CalledStrikes: 49,
+	}
+	if d != want {
+		t.Errorf("Discipline.Add tallied %+v, want %+v", d, want)
+	}
+
+	var testCases = []struct {
+		Name string
+		Got  float64
+		Want float64
+	}{
+		{"ZoneRate", d.ZoneRate(), 129.0 / 327},
+		{"SwingRate", d.SwingRate(), 156.0 / 327},
+		{"ZoneSwingRate", d.ZoneSwingRate(), 92.0 / 129},
+		{"ChaseRate", d.ChaseRate(), 64.0 / 198},
+		{"ContactRate", d.ContactRate(), 124.0 / 156},
+		{"ZoneContactRate", d.ZoneContactRate(), 80.0 / 92},
+		{"WhiffRate", d.WhiffRate(), 32.0 / 156},
+		{"CalledStrikeRate", d.CalledStrikeRate(), 49.0 / 327},
+	}
+	for _, tc := range testCases {
+		if math.Abs(tc.Got-tc.Want) > 1e-9 {
+			t.Errorf("Discipline.%v returned %v, want %v", tc.Name, tc.Got, tc.Want)
+		}
+	}
+
+	var merged Discipline
+	merged.Merge(d)
+	merged.Merge(d)
+	if merged.Swings != 312 || merged.SwingRate() != d.SwingRate() {
+		t.Errorf("Discipline.Merge returned %+v, want twice %+v", merged, d)
+	}
+	if (Discipline{}).ChaseRate() != 0 {
+		t.Errorf("Discipline.ChaseRate returned %v without pitches, want 0", Discipline{}.ChaseRate())
+	}
+}
+
+func TestSplit(t *testing.T) {
+	abs := new(AtBats)
+	readMock(t, "./mock/inning_all.xml", abs)
+
+	var s Split
+	for _, inning := range abs.Innings {
+		for i := range inning.Top {
+			if ab := &inning.Top[i]; ab.IsPlateAppearance() {
+				s.AddPlateAppearance(ab)
+				for j := range ab.Pitches {
+					s.Add(&ab.Pitches[j])
+				}
+			}
+		}
+	}
+
+	box := ComputeBoxScore(abs)
+	bb, so := 0, 0
+	for _, l := range box.Away.Batting {
+		bb += l.BB
+		so += l.SO
+	}
+	if s.PlateAppearances != 46 || s.Hits != box.Away.Hits || s.HomeRuns != 2 ||
+		s.Walks != bb || s.Strikeouts != so || s.Pitches != 180 {
+		t.Errorf("Split tallied %+v, want %v hits, 2 home runs, %v walks and %v strikeouts in 46 plate appearances",
+			s, box.Away.Hits, bb, so)
+	}
+}
+
+func TestPitchSwing(t *testing.T) {
+	var testCases = []struct {
+		Pitch  Pitch
+		Swing  bool
+		Whiff  bool
+		InPlay bool
+	}{
+		{Pitch{Des: "Ball", Type: "B"}, false, false, false},
+		{Pitch{Des: "Called Strike", Type: "S"}, false, false, false},
+		{Pitch{Des: "Foul Tip", Type: "S"}, true, false, false},
+		{Pitch{Des: "Foul (Runner Going)", Type: "S"}, true, false, false},
+		{Pitch{Des: "Swinging Strike (Blocked)", Type: "S"}, true, true, false},
+		{Pitch{Des: "Missed Bunt", Type: "S"}, true, true, false},
+		{Pitch{Des: "In play, run(s)", Type: "X"}, true, false, true},
+		{Pitch{Des: "Hit By Pitch", Type: "B"}, false, false, false},
+	}
+	for _, tc := range testCases {
+		p := tc.Pitch
+		if p.IsSwing() != tc.Swing || p.IsWhiff() != tc.Whiff || p.IsInPlay() != tc.InPlay {
+			t.Errorf("Pitch %v returned swing %v, whiff %v and in play %v, want %v, %v and %v",
+				p.Des, p.IsSwing(), p.IsWhiff(), p.IsInPlay(), tc.Swing, tc.Whiff, tc.InPlay)
+		}
+	}
+
+	for zone, want := range map[float32]bool{0: false, 1: true, 9: true, 11: false} {
+		if got := (&Pitch{Zone: zone}).InZone(); got != want {
+			t.Errorf("Pitch.InZone returned %v for zone %v, want %v", got, zone, want)
+		}
+	}
+}
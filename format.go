@@ -0,0 +1,187 @@
+package mlbgameday
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Format identifies the format in which resources are retrieved from the MLB
+// Gameday API.
+type Format int
+
+const (
+	// XML retrieves the .xml variant of each resource. It is the default.
+	XML Format = iota
+
+	// JSON retrieves the .json variant of each resource.
+	JSON
+)
+
+// ext returns the file extension of resources in this format.
+func (f Format) ext() string {
+	if f == JSON {
+		return ".json"
+	}
+	return ".xml"
+}
+
+// unmarshal decodes data in this format into v.
+func (f Format) unmarshal(data []byte, v interface{}) error {
+	if f == JSON {
+		return unmarshalJSON(data, v)
+	}
+	return xml.Unmarshal(data, v)
+}
+
+// String returns the name of the format.
+func (f Format) String() string {
+	switch f {
+	case XML:
+		return "XML"
+	case JSON:
+		return "JSON"
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// unmarshalJSON decodes a Gameday JSON document into v, which is annotated
+// with xml struct tags.
+//
+// Gameday JSON documents mirror their XML siblings: each element becomes an
+// object, attributes and text-only elements become string members, and
+// repeated elements become arrays. The document is rewritten as XML so that
+// both formats decode through the same struct tags and produce identical
+// values.
+func unmarshalJSON(data []byte, v interface{}) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	var doc map[string]interface{}
+	if err := d.Decode(&doc); err != nil {
+		return err
+	}
+
+	if inner, ok := doc["data"].(map[string]interface{}); ok {
+		doc = inner
+	}
+	if len(doc) != 1 {
+		return errors.New("JSON document does not have a single root element")
+	}
+
+	var buf bytes.Buffer
+	e := xml.NewEncoder(&buf)
+	for name, root := range doc {
+		if err := encodeJSONElement(e, name, root); err != nil {
+			return err
+		}
+	}
+	if err := e.Flush(); err != nil {
+		return err
+	}
+
+	return xml.Unmarshal(buf.Bytes(), v)
+}
+
+// encodeJSONElement writes the JSON value v as one or more XML elements
+// named name. Scalar members of an object are written both as attributes and
+// as text-only child elements, since the JSON does not say which they were.
+// Names are rewritten as valid XML names; see xmlName.
+func encodeJSONElement(e *xml.Encoder, name string, v interface{}) error {
+	start := xml.StartElement{Name: xml.Name{Local: xmlName(name)}}
+
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		for _, item := range v {
+			if err := encodeJSONElement(e, name, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		seen := make(map[string]bool)
+		for _, k := range keys {
+			s, ok := jsonScalar(v[k])
+			if !ok {
+				continue
+			}
+			// Keys that differ only in invalid characters would repeat
+			// an attribute; the first in order is kept.
+			attr := xmlName(k)
+			if seen[attr] {
+				continue
+			}
+			seen[attr] = true
+			start.Attr = append(start.Attr,
+				xml.Attr{Name: xml.Name{Local: attr}, Value: s})
+		}
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+		for _, k := range keys {
+			if err := encodeJSONElement(e, k, v[k]); err != nil {
+				return err
+			}
+		}
+		return e.EncodeToken(start.End())
+	}
+
+	s, _ := jsonScalar(v)
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if err := e.EncodeToken(xml.CharData(s)); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+// xmlName returns a JSON key as a valid XML name, without a namespace
+// prefix. Characters that may not appear in a name, including colons, are
+// replaced with underscores, and a name that does not start with a letter or
+// an underscore is prefixed with one. Keys that are already valid names,
+// such as every key of the Gameday documents, are returned unchanged.
+func xmlName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.' {
+			return r
+		}
+		return '_'
+	}, key)
+	if name == "" {
+		return "_"
+	}
+	if r := []rune(name)[0]; !unicode.IsLetter(r) && r != '_' {
+		name = "_" + name
+	}
+	return name
+}
+
+// jsonScalar returns the string form of a JSON string, number or boolean.
+func jsonScalar(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		if v {
+			return "true", true
+		}
+		return "false", true
+	}
+	return "", false
+}
@@ -0,0 +1,138 @@
+package mlbgameday
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestFormatJSONMatchesXML(t *testing.T) {
+	var testCases = []struct {
+		Name string
+		New  func() interface{}
+	}{
+		{"linescore", func() interface{} { return new(LineScore) }},
+		{"miniscoreboard", func() interface{} { return new(Scoreboard) }},
+		{"master_scoreboard", func() interface{} { return new(MasterScoreboard) }},
+		{"players", func() interface{} { return new(Players) }},
+		{"batter", func() interface{} { return new(Batter) }},
+		{"pitcher", func() interface{} { return new(Pitcher) }},
+		{"inning_top", func() interface{} { return new(AtBats) }},
+		{"notifications_full", func() interface{} { return new(Notifications) }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			xmlData, err := ioutil.ReadFile("./mock/" + tc.Name + ".xml")
+			if err != nil {
+				t.Fatal("Could not read data file")
+			}
+			jsonData, err := ioutil.ReadFile("./mock/" + tc.Name + ".json")
+			if err != nil {
+				t.Fatal("Could not read data file")
+			}
+
+			want := tc.New()
+			if err := xml.Unmarshal(xmlData, want); err != nil {
+				t.Fatalf("xml.Unmarshal returned error: %v", err)
+			}
+
+			got := tc.New()
+			if err := JSON.unmarshal(jsonData, got); err != nil {
+				t.Fatalf("JSON.unmarshal returned error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("JSON decoded %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFormatJSONErrorRoot(t *testing.T) {
+	data := []byte(`{"data": {"game": {}, "games": {}}}`)
+
+	err := JSON.unmarshal(data, new(Scoreboard))
+	testError(t, "JSON.unmarshal",
+		"JSON document does not have a single root element", err)
+}
+
+func TestFormatJSONErrorEOF(t *testing.T) {
+	err := JSON.unmarshal([]byte(""), new(Scoreboard))
+	testError(t, "JSON.unmarshal", "EOF", err)
+}
+
+func TestFormatJSONInvalidNames(t *testing.T) {
+	data := []byte(`{"data": {"game": {
+		"gameday_link": "2016_09_05_kcamlb_minmlb_1",
+		"1st": "a", "home team": "b", "$ref": "c", "ns:id": "d", "": "e",
+		"home_team": "f", "inning": "7", "is_no_hitter": "<N&>",
+		"2": {"linescore": [{"inning": "1"}]}
+	}}}`)
+
+	got := new(LineScore)
+	if err := JSON.unmarshal(data, got); err != nil {
+		t.Fatalf("JSON.unmarshal returned error: %v", err)
+	}
+	if got.GID != "2016_09_05_kcamlb_minmlb_1" || got.Inning != 7 || got.NoHitter != "<N&>" {
+		t.Errorf("JSON.unmarshal returned %+v", got)
+	}
+
+	var testCases = []struct {
+		Key  string
+		Want string
+	}{
+		{"gameday", "gameday"},
+		{"home_team_code", "home_team_code"},
+		{"x-y.z", "x-y.z"},
+		{"1st", "_1st"},
+		{"home team", "home_team"},
+		{"$ref", "_ref"},
+		{"ns:id", "ns_id"},
+		{"-a", "_-a"},
+		{"", "_"},
+	}
+	for _, tc := range testCases {
+		if got := xmlName(tc.Key); got != tc.Want {
+			t.Errorf("xmlName(%q) returned %q, want %q", tc.Key, got, tc.Want)
+		}
+	}
+}
+
+func TestLineScoreJSON(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data, err := ioutil.ReadFile("./mock/linescore.json")
+	if err != nil {
+		t.Fatal("Could not read data file")
+	}
+
+	gid := "2016_09_05_kcamlb_minmlb_1"
+	path := "/components/game/mlb/year_2016/month_09/day_05/" +
+		"gid_" + gid + "/linescore.json"
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, string(data))
+	})
+
+	client.Format = JSON
+	gameday := setupGameday()
+	game, err := gameday.Game(gid)
+	if err != nil {
+		t.Fatalf("Games.ByGID returned error: %v", err)
+	}
+
+	got, err := game.LineScore()
+	if err != nil {
+		t.Fatalf("Game.LineScore returned error: %v", err)
+	}
+
+	if got.GID != gid || got.Inning != 7 || len(got.Innings) != 7 {
+		t.Errorf("Game.LineScore returned %v", got)
+	}
+}
@@ -0,0 +1,320 @@
+// Package framing estimates how many strikes catchers gain or lose by the way
+// they receive pitches.
+//
+// A Model holds the rate at which pitches are called strikes by location,
+// count and the handedness of the pitcher and batter, learned from the called
+// pitches of a set of games. A catcher's extra strikes are the strikes called
+// while he was catching less the strikes the model expected.
+package framing
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/ericdreeves/mlbgameday"
+)
+
+// Game holds the data of a game needed to evaluate its catchers.
+type Game struct {
+	Players *mlbgameday.Players
+	AtBats  *mlbgameday.AtBats
+}
+
+// Load returns the players and at-bats of a game.
+func Load(game mlbgameday.GameService) (Game, error) {
+	players, err := game.Players()
+	if err != nil {
+		return Game{}, err
+	}
+	abs, err := game.AtBats()
+	if err != nil {
+		return Game{}, err
+	}
+	return Game{players, abs}, nil
+}
+
+// Counts of the called pitches in a Key.
+const (
+	EvenCount    = iota // As many balls as strikes
+	PitcherAhead        // More strikes than balls
+	BatterAhead         // More balls than strikes
+)
+
+// countOf returns the kind of a count.
+func countOf(balls, strikes int) int {
+	switch {
+	case strikes > balls:
+		return PitcherAhead
+	case balls > strikes:
+		return BatterAhead
+	}
+	return EvenCount
+}
+
+// binSize is the width and, relative to the height of the batter's strike
+// zone, the height of the location bins of a Key.
+const binSize = 1.0 / 6
+
+// The strike zone of a typical batter, in feet, by which the height of a
+// location bin is scaled to classify it with a Zone.
+const (
+	typicalBottom = 1.5
+	typicalTop    = 3.5
+)
+
+// Key identifies the circumstances of a called pitch.
+type Key struct {
+	// X and Z are the column and row of the bin of the pitch's location:
+	// PX and the height of PZ relative to SZBot in units of the height of
+	// the batter's strike zone, each divided into bins of 1/6.
+	X int
+	Z int
+
+	// Count is EvenCount, PitcherAhead or BatterAhead.
+	Count int
+
+	// PThrows and Stand are the throwing hand of the pitcher and the side
+	// of the plate of the batter, "L" or "R".
+	PThrows string
+	Stand   string
+}
+
+// location returns the location part of the key.
+func (k Key) location() Key {
+	return Key{X: k.X, Z: k.Z}
+}
+
+// KeyOf returns the key of a called pitch in an at-bat on a count.
+func KeyOf(ab *mlbgameday.AtBat, p *mlbgameday.Pitch, balls, strikes int) Key {
+	z := float64(p.PZ-p.SZBot) / float64(p.SZTop-p.SZBot)
+	return Key{
+		X:       int(math.Floor(float64(p.PX) / binSize)),
+		Z:       int(math.Floor(z / binSize)),
+		Count:   countOf(balls, strikes),
+		PThrows: ab.PThrows,
+		Stand:   ab.Stand,
+	}
+}
+
+// calls holds the number of called pitches and strikes.
+type calls struct {
+	Called  int
+	Strikes int
+}
+
+// Model represents the rate at which pitches are called strikes. The zero
+// Model is empty, with a Weight of 0, and ready to use; NewModel returns one
+// with the default Weight.
+type Model struct {
+	// Zone classifies pitches in bins that were never observed.
+	Zone mlbgameday.Zone
+
+	// Weight is the number of calls with which the strike rate of a bin's
+	// location is weighted against the calls observed in a key, and the
+	// classification of Zone against the calls observed at a location.
+	Weight float64
+
+	keys      map[Key]*calls
+	locations map[Key]*calls
+}
+
+// NewModel returns a new empty Model.
+func NewModel() *Model {
+	return &Model{
+		Weight:    5,
+		keys:      make(map[Key]*calls),
+		locations: make(map[Key]*calls),
+	}
+}
+
+// BuildModel returns the model of the called pitches of a set of games.
+func BuildModel(games ...Game) *Model {
+	m := NewModel()
+	for _, g := range games {
+		m.Add(g.AtBats)
+	}
+	return m
+}
+
+// Add adds the called pitches of a game to the model.
+func (m *Model) Add(abs *mlbgameday.AtBats) {
+	if m.keys == nil {
+		m.keys = make(map[Key]*calls)
+		m.locations = make(map[Key]*calls)
+	}
+
+	walkCalls(Game{AtBats: abs}, func(c call) {
+		k := KeyOf(c.ab, c.p, c.balls, c.strikes)
+		for _, e := range []struct {
+			table map[Key]*calls
+			key   Key
+		}{{m.keys, k}, {m.locations, k.location()}} {
+			n, ok := e.table[e.key]
+			if !ok {
+				n = new(calls)
+				e.table[e.key] = n
+			}
+			n.Called++
+			if c.p.Type == "S" {
+				n.Strikes++
+			}
+		}
+	})
+}
+
+// StrikeProbability returns the probability that a pitch with key k is
+// called a strike. The strike rate observed in the key is weighted against
+// that at its location, which is weighted against whether the center of the
+// location bin is in Zone for a typical batter.
+func (m *Model) StrikeProbability(k Key) float64 {
+	height := typicalTop - typicalBottom
+	center := &mlbgameday.Pitch{
+		PX:    float32((float64(k.X) + 0.5) * binSize),
+		PZ:    float32(typicalBottom + (float64(k.Z)+0.5)*binSize*height),
+		SZTop: typicalTop,
+		SZBot: typicalBottom,
+	}
+	prior := 0.0
+	if m.Zone.Contains(center) {
+		prior = 1
+	}
+
+	p := smooth(m.locations[k.location()], prior, m.Weight)
+	return smooth(m.keys[k], p, m.Weight)
+}
+
+// smooth returns the strike rate of the calls weighted against a prior
+// probability.
+func smooth(c *calls, prior, weight float64) float64 {
+	if c == nil {
+		return prior
+	}
+	return (float64(c.Strikes) + weight*prior) / (float64(c.Called) + weight)
+}
+
+// Catcher represents the framing of a catcher.
+type Catcher struct {
+	ID int
+
+	// Called is the number of called pitches he received, Strikes the
+	// number called strikes and Expected the number of strikes the model
+	// expected.
+	Called   int
+	Strikes  int
+	Expected float64
+}
+
+// ExtraStrikes returns the strikes called on pitches the catcher received
+// beyond those expected.
+func (c Catcher) ExtraStrikes() float64 {
+	return float64(c.Strikes) - c.Expected
+}
+
+// Evaluate returns the framing of every catcher in a set of games, ordered by
+// extra strikes, most first. Pitches received by a catcher who could not be
+// identified are skipped.
+func (m *Model) Evaluate(games ...Game) []Catcher {
+	index := make(map[int]int)
+	var catchers []Catcher
+	for _, g := range games {
+		walkCalls(g, func(c call) {
+			if c.catcher == 0 {
+				return
+			}
+			i, ok := index[c.catcher]
+			if !ok {
+				i = len(catchers)
+				index[c.catcher] = i
+				catchers = append(catchers, Catcher{ID: c.catcher})
+			}
+
+			catchers[i].Called++
+			if c.p.Type == "S" {
+				catchers[i].Strikes++
+			}
+			catchers[i].Expected += m.StrikeProbability(KeyOf(c.ab, c.p, c.balls, c.strikes))
+		})
+	}
+
+	sort.SliceStable(catchers, func(i, j int) bool {
+		return catchers[i].ExtraStrikes() > catchers[j].ExtraStrikes()
+	})
+	return catchers
+}
+
+// Estimate returns the framing of every catcher in a set of games, evaluated
+// with the model of the same games.
+func Estimate(games ...Game) []Catcher {
+	return BuildModel(games...).Evaluate(games...)
+}
+
+// call represents a called pitch with its count and catcher.
+type call struct {
+	ab             *mlbgameday.AtBat
+	p              *mlbgameday.Pitch
+	balls, strikes int
+	catcher        int
+}
+
+// walkCalls calls fn for each called pitch of a game with a location.
+//
+// Each team's catcher starts as the player on its roster whose GamePosition
+// is C, and changes with every substitution or switch that puts a player at
+// catcher. Players that are not on either roster are taken to have joined the
+// fielding team.
+func walkCalls(g Game, fn func(call)) {
+	var catcher [2]int
+	team := make(map[int]int)
+	if g.Players != nil {
+		for i, r := range g.Players.Teams {
+			if i > 1 {
+				break
+			}
+			for _, p := range r.Players {
+				team[p.ID] = i
+				if p.GamePosition == "C" {
+					catcher[i] = p.ID
+				}
+			}
+		}
+	}
+
+	mlbgameday.WalkGameStates(g.AtBats, func(step mlbgameday.GameStep) error {
+		// The home team fields in the top of the inning.
+		fielding := 1
+		if !step.Before.TopInning {
+			fielding = 0
+		}
+
+		switch step.Kind {
+		case mlbgameday.ActionStep:
+			if a := step.Action; a.Player != 0 && isCatcherChange(a) {
+				i, ok := team[a.Player]
+				if !ok {
+					i = fielding
+				}
+				catcher[i] = a.Player
+			}
+		case mlbgameday.PitchStep:
+			p := step.Pitch
+			if p.IsCalled() && p.HasLocation() {
+				fn(call{step.AtBat, p, step.Before.Balls, step.Before.Strikes, catcher[fielding]})
+			}
+		}
+		return nil
+	})
+}
+
+// isCatcherChange returns whether an action puts its player at catcher.
+func isCatcherChange(a *mlbgameday.Action) bool {
+	if a.Event != "Defensive Sub" && a.Event != "Defensive Switch" {
+		return false
+	}
+	for _, s := range []string{"playing catcher", "as the catcher", "to catcher"} {
+		if strings.Contains(a.Des, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package framing
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ericdreeves/mlbgameday"
+	"github.com/ericdreeves/mlbgameday/internal/testutil"
+)
+
+func readGame(t *testing.T) Game {
+	game, err := Load(testutil.NewGame(t))
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	return game
+}
+
+func TestEstimate(t *testing.T) {
+	g := readGame(t)
+	catchers := Estimate(g)
+
+	// Salvador Perez starts for the away team and is replaced by Drew Butera
+	// in the 9th. The home team's starting catcher is not on the roster, so
+	// its catcher is known only once Kurt Suzuki stays in the game in the
+	// 8th.
+	byID := make(map[int]Catcher)
+	for _, c := range catchers {
+		byID[c.ID] = c
+	}
+	if len(catchers) != 3 {
+		t.Fatalf("Estimate returned %+v, want 3 catchers", catchers)
+	}
+
+	var testCases = []struct {
+		ID      int
+		Called  int
+		Strikes int
+	}{
+		{521692, 72, 25},
+		{460077, 5, 1},
+		{435559, 20, 6},
+	}
+	for _, tc := range testCases {
+		c := byID[tc.ID]
+		if c.Called != tc.Called || c.Strikes != tc.Strikes {
+			t.Errorf("Estimate returned %+v for catcher %v, want %v strikes of %v calls",
+				c, tc.ID, tc.Strikes, tc.Called)
+		}
+		if c.Expected <= 0 || c.Expected > float64(c.Called) {
+			t.Errorf("Estimate expected %v strikes for catcher %v, want 0 to %v",
+				c.Expected, tc.ID, c.Called)
+		}
+	}
+
+	for i := 1; i < len(catchers); i++ {
+		if catchers[i].ExtraStrikes() > catchers[i-1].ExtraStrikes() {
+			t.Errorf("Estimate returned catchers out of order: %+v", catchers)
+		}
+	}
+
+	_, err := Load(&testutil.Game{Err: errors.New("Not found")})
+	if err == nil || err.Error() != "Not found" {
+		t.Errorf("Load returned error %v, want Not found", err)
+	}
+}
+
+func TestStrikeProbability(t *testing.T) {
+	m := NewModel()
+
+	// Without observations, the bin is classified by the zone.
+	middle := Key{X: 0, Z: 3, PThrows: "R", Stand: "L"}
+	outside := Key{X: 6, Z: 3, PThrows: "R", Stand: "L"}
+	if p := m.StrikeProbability(middle); p != 1 {
+		t.Errorf("Model.StrikeProbability returned %v in the middle of the zone, want 1", p)
+	}
+	if p := m.StrikeProbability(outside); p != 0 {
+		t.Errorf("Model.StrikeProbability returned %v outside the zone, want 0", p)
+	}
+
+	m = BuildModel(readGame(t))
+	total := 0.0
+	for k, c := range m.keys {
+		p := m.StrikeProbability(k)
+		if p < 0 || p > 1 {
+			t.Errorf("Model.StrikeProbability(%+v) returned %v, want 0 to 1", k, p)
+		}
+		total += float64(c.Called)
+	}
+	if total != 170 {
+		t.Errorf("BuildModel observed %v calls, want 170", total)
+	}
+
+	// The zero Model is ready to use.
+	var zero Model
+	zero.Add(testutil.AtBats(t))
+	if len(zero.keys) != len(m.keys) || len(zero.locations) != len(m.locations) {
+		t.Errorf("Model.Add observed %v keys in a zero Model, want %v", len(zero.keys), len(m.keys))
+	}
+}
+
+func TestKeyOf(t *testing.T) {
+	ab := &mlbgameday.AtBat{PThrows: "L", Stand: "R"}
+	p := &mlbgameday.Pitch{PX: -0.1, PZ: 2, SZTop: 3.5, SZBot: 1.5}
+	want := Key{-1, 1, PitcherAhead, "L", "R"}
+	if k := KeyOf(ab, p, 0, 2); k != want {
+		t.Errorf("KeyOf returned %+v, want %+v", k, want)
+	}
+
+	if countOf(3, 2) != BatterAhead || countOf(1, 1) != EvenCount {
+		t.Errorf("countOf returned %v for 3-2 and %v for 1-1, want BatterAhead and EvenCount",
+			countOf(3, 2), countOf(1, 1))
+	}
+}
@@ -1,7 +1,6 @@
 package mlbgameday
 
 import (
-	"encoding/xml"
 	"errors"
 	"fmt"
 	"strings"
@@ -12,10 +11,14 @@ import (
 type GameService interface {
 	LineScore() (*LineScore, error)
 	Players() (*Players, error)
+	Batter(int) (*Batter, error)
+	Pitcher(int) (*Pitcher, error)
 	AtBats() (*AtBats, error)
 	CurrentAtBat() (*AtBat, error)
 	FilterAtBats(func(*AtBat) bool) (*AtBats, error)
+	HitChart() (*HitChart, error)
 	Notifications() (*Notifications, error)
+	ListResources() ([]string, error)
 }
 
 // GameServiceOp communicates with the MLB Gameday API to retrieve information
@@ -29,13 +32,29 @@ type GameServiceOp struct {
 
 	// The path to the MLB Gameday API at which resources for this game reside.
 	path string
+
+	// The paths to each part of a suspended game that was resumed on a later
+	// date, in order of play. Empty for games played on a single date.
+	parts []string
 }
 
 // NewGameService returns a new GameServiceOp that is linked to the
 // provided game ID. Communication with the MLB Gameday API occurs through
-// the provided Client.
+// the provided Client. The game's sport is inferred from the game ID and
+// defaults to MLB.
 func NewGameService(client *Client, gid string) (GameService, error) {
-	path, err := pathFromGID(gid, "")
+	sport, ok := SportFromGID(gid)
+	if !ok {
+		sport = MLB
+	}
+
+	return NewSportGameService(client, sport, gid)
+}
+
+// NewSportGameService returns a new GameServiceOp that is linked to the
+// provided game ID in the folder of the provided sport. See NewGameService.
+func NewSportGameService(client *Client, sport Sport, gid string) (GameService, error) {
+	path, err := pathFromGID(gid, sport, "")
 	if err != nil {
 		return nil, err
 	}
@@ -45,13 +64,8 @@ func NewGameService(client *Client, gid string) (GameService, error) {
 
 // LineScore retrieves the line score for this game.
 func (s *GameServiceOp) LineScore() (*LineScore, error) {
-	data, err := s.client.get(s.path + "linescore.xml")
-	if err != nil {
-		return nil, err
-	}
-
 	ls := new(LineScore)
-	if err = xml.Unmarshal(data, &ls); err != nil {
+	if err := s.getResource("linescore", ls); err != nil {
 		return nil, err
 	}
 
@@ -60,13 +74,30 @@ func (s *GameServiceOp) LineScore() (*LineScore, error) {
 
 // Players returns the team rosters for this game.
 func (s *GameServiceOp) Players() (*Players, error) {
-	data, err := s.client.get(s.path + "players.xml")
-	if err != nil {
+	p := new(Players)
+	if err := s.getResource("players", p); err != nil {
 		return nil, err
 	}
 
-	p := new(Players)
-	if err = xml.Unmarshal(data, &p); err != nil {
+	return p, nil
+}
+
+// Batter returns the season, career and situational stats for the batter
+// with the provided player ID, as published for this game.
+func (s *GameServiceOp) Batter(id int) (*Batter, error) {
+	b := new(Batter)
+	if err := s.getResource(fmt.Sprintf("batters/%d", id), b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Pitcher returns the season, career and situational stats for the pitcher
+// with the provided player ID, as published for this game.
+func (s *GameServiceOp) Pitcher(id int) (*Pitcher, error) {
+	p := new(Pitcher)
+	if err := s.getResource(fmt.Sprintf("pitchers/%d", id), p); err != nil {
 		return nil, err
 	}
 
@@ -74,15 +105,15 @@ func (s *GameServiceOp) Players() (*Players, error) {
 }
 
 // AtBats returns all at-bats for this game, including any that are in
-// progress.
+// progress. The at-bats of a resumed game span every date on which the game
+// was played.
 func (s *GameServiceOp) AtBats() (*AtBats, error) {
-	data, err := s.client.get(s.path + "inning/inning_all.xml")
-	if err != nil {
-		return nil, err
+	if len(s.parts) > 0 {
+		return s.resumedAtBats()
 	}
 
 	g := new(AtBats)
-	if err = xml.Unmarshal(data, &g); err != nil {
+	if err := s.client.getResource(s.path+"inning/inning_all", g); err != nil {
 		return nil, err
 	}
 
@@ -98,18 +129,7 @@ func (s *GameServiceOp) CurrentAtBat() (*AtBat, error) {
 		return nil, err
 	}
 
-	i, top, err := lastHalfInning(g)
-	if err != nil {
-		return nil, err
-	}
-	inning := g.Innings[i]
-	abs := inning.Bottom
-	if top == "Y" {
-		abs = inning.Top
-	}
-	ab := abs[len(abs)-1]
-
-	return &ab, nil
+	return currentAtBat(g)
 }
 
 // FilterAtBats returns all at-bats for which the evaluation in the provided
@@ -120,53 +140,114 @@ func (s *GameServiceOp) FilterAtBats(f func(*AtBat) bool) (*AtBats, error) {
 		return nil, err
 	}
 
-	abs := AtBats{}
-	for _, inning := range all.Innings {
-		inn := AtBatInning{Number: inning.Number}
-		for _, ab := range inning.Top {
-			if f(&ab) {
-				inn.Top = append(inn.Top, ab)
+	return filterAtBats(all, f), nil
+}
+
+// HitChart returns the locations of the balls put in play during this game.
+// The hit chart of a resumed game spans every date on which the game was
+// played, with balls listed on more than one date included once.
+func (s *GameServiceOp) HitChart() (*HitChart, error) {
+	parts := s.parts
+	if len(parts) == 0 {
+		parts = []string{s.path}
+	}
+
+	chart := new(HitChart)
+	seen := make(map[HitLocation]bool)
+	for i, part := range parts {
+		c := new(HitChart)
+		if err := s.client.getResource(part+"inning/inning_hit", c); err != nil {
+			if e, ok := err.(*HTTPError); ok && e.StatusCode == 404 && i > 0 {
+				continue
 			}
+			return nil, err
 		}
-		for _, ab := range inning.Bottom {
-			if f(&ab) {
-				inn.Bottom = append(inn.Bottom, ab)
+		for _, h := range c.Hits {
+			if !seen[h] {
+				seen[h] = true
+				chart.Hits = append(chart.Hits, h)
 			}
 		}
-		if len(inn.Top) > 0 || len(inn.Bottom) > 0 {
-			abs.Innings = append(abs.Innings, inn)
-		}
 	}
 
-	return &abs, nil
+	return chart, nil
 }
 
 // Notifications returns all notifications for this game: general game
 // notifications as well as notifications by team/inning.
 func (s *GameServiceOp) Notifications() (*Notifications, error) {
-	data, err := s.client.get(s.path + "notifications/notifications_full.xml")
-	if err != nil {
+	n := new(Notifications)
+	if err := s.getResource("notifications/notifications_full", n); err != nil {
 		return nil, err
 	}
 
-	n := new(Notifications)
-	if err = xml.Unmarshal(data, &n); err != nil {
+	return n, nil
+}
+
+// ListResources lists the files and subdirectories published in this game's
+// folder. Subdirectories are listed with a trailing slash.
+func (s *GameServiceOp) ListResources() ([]string, error) {
+	data, err := s.client.get(s.path)
+	if e, ok := err.(*HTTPError); ok && e.StatusCode == 404 && len(s.parts) > 0 {
+		data, err = s.client.get(s.parts[0])
+	}
+	if err != nil {
 		return nil, err
 	}
 
-	return n, nil
+	return parseListing(data), nil
 }
 
 // pathFromGID returns the URL path to a resource provided by the MLB
-// Gameday API based on the provided gid.
-func pathFromGID(gid string, path string) (string, error) {
+// Gameday API based on the provided gid and sport.
+func pathFromGID(gid string, sport Sport, path string) (string, error) {
 	toks := strings.Split((string)(gid), "_")
 	if len(toks) != 6 {
 		return "", errors.New("Could not derive date from id " + gid)
 	}
 
 	gDate := fmt.Sprintf("year_%s/month_%s/day_%s", toks[0], toks[1], toks[2])
-	return fmt.Sprintf("components/game/mlb/%s/gid_%s/%s", gDate, gid, path), nil
+	return fmt.Sprintf("%s%s/gid_%s/%s", sport.path(), gDate, gid, path), nil
+}
+
+// currentAtBat returns the last at-bat of the most recent half inning.
+func currentAtBat(g *AtBats) (*AtBat, error) {
+	i, top, err := lastHalfInning(g)
+	if err != nil {
+		return nil, err
+	}
+	inning := g.Innings[i]
+	abs := inning.Bottom
+	if top == "Y" {
+		abs = inning.Top
+	}
+	ab := abs[len(abs)-1]
+
+	return &ab, nil
+}
+
+// filterAtBats returns the at-bats in all for which f returns true. Innings
+// without any matching at-bats are omitted.
+func filterAtBats(all *AtBats, f func(*AtBat) bool) *AtBats {
+	abs := AtBats{}
+	for _, inning := range all.Innings {
+		inn := AtBatInning{Number: inning.Number}
+		for _, ab := range inning.Top {
+			if f(&ab) {
+				inn.Top = append(inn.Top, ab)
+			}
+		}
+		for _, ab := range inning.Bottom {
+			if f(&ab) {
+				inn.Bottom = append(inn.Bottom, ab)
+			}
+		}
+		if len(inn.Top) > 0 || len(inn.Bottom) > 0 {
+			abs.Innings = append(abs.Innings, inn)
+		}
+	}
+
+	return &abs
 }
 
 // lastHalfInning returns the most recent half inning, i.e. the inning number
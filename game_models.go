@@ -29,6 +29,34 @@ type Game struct {
 	// 4: 1B,2B, 5: 1B,3B, 6: 2B,3B
 	// 7: 1B,2B,3B
 	BaseState int `xml:"runner_on_base_status,attr"`
+
+	// GameNumber is 2 for the second game of a doubleheader and 1 otherwise.
+	GameNumber int `xml:"game_nbr,attr"`
+
+	// DoubleHeader indicates whether the game is part of a doubleheader.
+	// N: No, Y: Traditional doubleheader, S: Split doubleheader
+	DoubleHeader string `xml:"double_header_sw,attr"`
+
+	// OriginalDate is the date, as YYYY/MM/DD, on which the game was
+	// originally scheduled. It differs from the date of the game's GID
+	// for games that were postponed.
+	OriginalDate string `xml:"original_date,attr"`
+
+	// ResumeDate is the date, as YYYY/MM/DD, on which a suspended game will
+	// be resumed.
+	ResumeDate string `xml:"resume_date,attr"`
+
+	// AwayTimeZone and HomeTimeZone are the abbreviations of the time zones
+	// of the away and home teams, e.g. "CT".
+	AwayTimeZone string `xml:"away_time_zone,attr"`
+	HomeTimeZone string `xml:"home_time_zone,attr"`
+
+	// HomeLeagueTimeDate is the start time of the game, as YYYY/MM/DD H:MM,
+	// in the time zone of the home team's league, whose offset from UTC in
+	// hours is HomeLeagueTimeZone.
+	HomeLeagueTimeDate string `xml:"time_date_hm_lg,attr"`
+	HomeLeagueAMPM     string `xml:"hm_lg_ampm,attr"`
+	HomeLeagueTimeZone int    `xml:"time_zone_hm_lg,attr"`
 }
 
 // LineScore represents the line score of a game, including runs by inning
@@ -86,6 +114,12 @@ type Player struct {
 	Wins     int     `xml:"wins,attr"`
 	Losses   int     `xml:"losses,attr"`
 	ERA      float32 `xml:"era,attr"`
+
+	// BatOrder is the player's slot in the batting order, from 1 to 9, and
+	// GamePosition the position he started the game at. Both are empty for
+	// players who did not start.
+	BatOrder     int    `xml:"bat_order,attr"`
+	GamePosition string `xml:"game_position,attr"`
 }
 
 // Coach represents a member of a team's coaching staff.
@@ -111,11 +145,15 @@ type AtBats struct {
 	Innings []AtBatInning `xml:"inning"`
 }
 
-// AtBatInning represents the at-bats that occur in both halves of an inning.
+// AtBatInning represents the at-bats that occur in both halves of an inning,
+// and the actions, such as stolen bases and substitutions, that occur between
+// pitches.
 type AtBatInning struct {
-	Number int     `xml:"num,attr"`
-	Top    []AtBat `xml:"top>atbat"`
-	Bottom []AtBat `xml:"bottom>atbat"`
+	Number        int      `xml:"num,attr"`
+	Top           []AtBat  `xml:"top>atbat"`
+	Bottom        []AtBat  `xml:"bottom>atbat"`
+	TopActions    []Action `xml:"top>action"`
+	BottomActions []Action `xml:"bottom>action"`
 }
 
 // AtBatSummary represents a single at-bat.
@@ -135,6 +173,85 @@ type AtBatSummary struct {
 type AtBat struct {
 	AtBatSummary
 	Pitches []Pitch `xml:"pitch"`
+
+	// EventNum orders the at-bat's result among the pitches and actions of
+	// the game.
+	EventNum int `xml:"event_num,attr"`
+
+	// Stand and PThrows are the sides from which the batter hit and the
+	// pitcher threw: L or R.
+	Stand   string `xml:"stand,attr"`
+	PThrows string `xml:"p_throws,attr"`
+	Des     string `xml:"des,attr"`
+
+	// Runners are the movements of the batter and runners during the
+	// at-bat, including those on actions such as stolen bases.
+	Runners []Runner `xml:"runner"`
+}
+
+// Runner represents the movement of a single runner on a play.
+type Runner struct {
+	ID int `xml:"id,attr"`
+
+	// Start and End are the bases, e.g. 1B, occupied by the runner before and
+	// after the play. Start is empty for the batter. End is empty for a
+	// runner who scored or was put out.
+	Start string `xml:"start,attr"`
+	End   string `xml:"end,attr"`
+	Event string `xml:"event,attr"`
+
+	// EventNum identifies the pitch, action or at-bat on which the runner
+	// moved.
+	EventNum int `xml:"event_num,attr"`
+
+	// Score, RBI and Earned are T if the runner scored, the batter was
+	// credited with an RBI and the run was earned.
+	Score  string `xml:"score,attr"`
+	RBI    string `xml:"rbi,attr"`
+	Earned string `xml:"earned,attr"`
+}
+
+// HitChart represents the locations of the balls put in play during a game.
+type HitChart struct {
+	Hits []HitLocation `xml:"hip"`
+}
+
+// HitLocation represents the location at which a ball put in play was
+// fielded, in the coordinates of the Gameday field diagram: X increases
+// toward right field and Y toward home plate.
+type HitLocation struct {
+	// Des is the result of the play, e.g. "Single".
+	Des     string  `xml:"des,attr"`
+	X       float32 `xml:"x,attr"`
+	Y       float32 `xml:"y,attr"`
+	Batter  int     `xml:"batter,attr"`
+	Pitcher int     `xml:"pitcher,attr"`
+
+	// Type is H for a hit, O for an out and E for an error. Team is A or H,
+	// the batting team.
+	Type   string `xml:"type,attr"`
+	Team   string `xml:"team,attr"`
+	Inning int    `xml:"inning,attr"`
+}
+
+// Action represents an event that occurs during or between at-bats, such as
+// a stolen base, wild pitch or substitution.
+type Action struct {
+	Balls   int    `xml:"b,attr"`
+	Strikes int    `xml:"s,attr"`
+	Outs    int    `xml:"o,attr"`
+	Des     string `xml:"des,attr"`
+	Event   string `xml:"event,attr"`
+
+	// Player is the player involved, e.g. the runner or the new pitcher.
+	Player int `xml:"player,attr"`
+
+	// Pitch is the number of pitches thrown in the at-bat before the action.
+	Pitch        int    `xml:"pitch,attr"`
+	EventNum     int    `xml:"event_num,attr"`
+	TFSZulu      string `xml:"tfs_zulu,attr"`
+	HomeTeamRuns int    `xml:"home_team_runs,attr"`
+	AwayTeamRuns int    `xml:"away_team_runs,attr"`
 }
 
 // Pitch represents the PitchF/X data for a single pitch.
@@ -170,6 +287,9 @@ type Pitch struct {
 	Nasty          float32 `xml:"nasty,attr"`
 	SpinDir        float32 `xml:"spin_dir,attr"`
 	SpinRate       float32 `xml:"spin_rate,attr"`
+
+	// EventNum orders the pitch among the pitches and actions of the game.
+	EventNum int `xml:"event_num,attr"`
 }
 
 // Notifications represents both game and team notifications.
@@ -199,3 +319,175 @@ type Notification struct {
 type Type struct {
 	Category string `xml:"category,attr"`
 }
+
+// Batter represents a batter's stats as of a game: season and career totals
+// plus splits against the opponent and by game situation.
+type Batter struct {
+	ID        int          `xml:"id,attr"`
+	Team      string       `xml:"team,attr"`
+	Position  string       `xml:"pos,attr"`
+	First     string       `xml:"first_name,attr"`
+	Last      string       `xml:"last_name,attr"`
+	Num       int          `xml:"jersey_number,attr"`
+	Bats      string       `xml:"bats,attr"`
+	Throws    string       `xml:"throws,attr"`
+	Season    BattingStats `xml:"season"`
+	Career    BattingStats `xml:"career"`
+	Month     BattingStats `xml:"month"`
+	Opponent  BattingStats `xml:"Team"`
+	Empty     BattingStats `xml:"Empty"`
+	MenOn     BattingStats `xml:"Men_On"`
+	RISP      BattingStats `xml:"RISP"`
+	Loaded    BattingStats `xml:"Loaded"`
+	VsLHP     BattingStats `xml:"vs_LHP"`
+	VsRHP     BattingStats `xml:"vs_RHP"`
+	VsPitcher BattingStats `xml:"vs_P"`
+}
+
+// BattingStats represents a batting stat line for a single split.
+type BattingStats struct {
+	Des string  `xml:"des,attr"`
+	Avg float32 `xml:"avg,attr"`
+	AB  int     `xml:"ab,attr"`
+	H   int     `xml:"h,attr"`
+	HR  int     `xml:"hr,attr"`
+	RBI int     `xml:"rbi,attr"`
+	BB  int     `xml:"bb,attr"`
+	SO  int     `xml:"so,attr"`
+}
+
+// Pitcher represents a pitcher's stats as of a game: season and career
+// totals plus splits against the opponent and by game situation.
+type Pitcher struct {
+	ID       int           `xml:"id,attr"`
+	Team     string        `xml:"team,attr"`
+	Position string        `xml:"pos,attr"`
+	First    string        `xml:"first_name,attr"`
+	Last     string        `xml:"last_name,attr"`
+	Num      int           `xml:"jersey_number,attr"`
+	Throws   string        `xml:"throws,attr"`
+	Season   PitchingStats `xml:"season"`
+	Career   PitchingStats `xml:"career"`
+	Month    PitchingStats `xml:"month"`
+	Opponent PitchingStats `xml:"Team"`
+	Empty    PitchingStats `xml:"Empty"`
+	MenOn    PitchingStats `xml:"Men_On"`
+	RISP     PitchingStats `xml:"RISP"`
+	Loaded   PitchingStats `xml:"Loaded"`
+	VsLHB    PitchingStats `xml:"vs_LHB"`
+	VsRHB    PitchingStats `xml:"vs_RHB"`
+	VsBatter PitchingStats `xml:"vs_B"`
+}
+
+// PitchingStats represents a pitching stat line for a single split.
+type PitchingStats struct {
+	Des    string  `xml:"des,attr"`
+	Avg    float32 `xml:"avg,attr"`
+	Wins   int     `xml:"w,attr"`
+	Losses int     `xml:"l,attr"`
+	ERA    float32 `xml:"era,attr"`
+	G      int     `xml:"g,attr"`
+	GS     int     `xml:"gs,attr"`
+	SV     int     `xml:"sv,attr"`
+	IP     float32 `xml:"ip,attr"`
+	H      int     `xml:"h,attr"`
+	BB     int     `xml:"bb,attr"`
+	SO     int     `xml:"so,attr"`
+	HR     int     `xml:"hr,attr"`
+	WHIP   float32 `xml:"whip,attr"`
+}
+
+// MasterGame represents a game on the master scoreboard. Its embedded Game is
+// populated from the game's status and line score so that it carries the same
+// information as a game from the miniscoreboard.
+type MasterGame struct {
+	Game
+	GameStatus          GameStatus          `xml:"status"`
+	LineScore           ScoreboardLineScore `xml:"linescore"`
+	HomeRuns            []HomeRun           `xml:"home_runs>player"`
+	WinningPitcher      ScoreboardPitcher   `xml:"winning_pitcher"`
+	LosingPitcher       ScoreboardPitcher   `xml:"losing_pitcher"`
+	SavePitcher         ScoreboardPitcher   `xml:"save_pitcher"`
+	AwayProbablePitcher ScoreboardPitcher   `xml:"away_probable_pitcher"`
+	HomeProbablePitcher ScoreboardPitcher   `xml:"home_probable_pitcher"`
+	Broadcast           Broadcast           `xml:"broadcast"`
+}
+
+// GameStatus represents the status of a game on the master scoreboard.
+type GameStatus struct {
+	Status      string `xml:"status,attr"`
+	Ind         string `xml:"ind,attr"`
+	Reason      string `xml:"reason,attr"`
+	Inning      int    `xml:"inning,attr"`
+	TopInning   string `xml:"top_inning,attr"`
+	Balls       int    `xml:"b,attr"`
+	Strikes     int    `xml:"s,attr"`
+	Outs        int    `xml:"o,attr"`
+	InningState string `xml:"inning_state,attr"`
+	Note        string `xml:"note,attr"`
+	NoHitter    string `xml:"is_no_hitter,attr"`
+	PerfectGame string `xml:"is_perfect_game,attr"`
+}
+
+// ScoreboardLineScore represents runs by inning and team totals for a game on
+// the master scoreboard.
+type ScoreboardLineScore struct {
+	Innings     []ScoreboardInning `xml:"inning"`
+	Runs        ScoreboardTotal    `xml:"r"`
+	Hits        ScoreboardTotal    `xml:"h"`
+	Errors      ScoreboardTotal    `xml:"e"`
+	HomeRuns    ScoreboardTotal    `xml:"hr"`
+	StrikeOuts  ScoreboardTotal    `xml:"so"`
+	StolenBases ScoreboardTotal    `xml:"sb"`
+}
+
+// ScoreboardInning represents runs scored by each team in a single inning.
+type ScoreboardInning struct {
+	Away int `xml:"away,attr"`
+	Home int `xml:"home,attr"`
+}
+
+// ScoreboardTotal represents a game total for each team.
+type ScoreboardTotal struct {
+	Away int `xml:"away,attr"`
+	Home int `xml:"home,attr"`
+}
+
+// HomeRun represents a home run hit during a game.
+type HomeRun struct {
+	ID       int    `xml:"id,attr"`
+	First    string `xml:"first,attr"`
+	Last     string `xml:"last,attr"`
+	Num      int    `xml:"number,attr"`
+	TeamCode string `xml:"team_code,attr"`
+	HR       int    `xml:"hr,attr"`
+	SeasonHR int    `xml:"std_hr,attr"`
+	Inning   int    `xml:"inning,attr"`
+	Runners  int    `xml:"runners,attr"`
+}
+
+// ScoreboardPitcher represents a probable, winning, losing or save pitcher
+// listed on the master scoreboard. ID is zero when no pitcher is listed.
+type ScoreboardPitcher struct {
+	ID           int     `xml:"id,attr"`
+	First        string  `xml:"first,attr"`
+	Last         string  `xml:"last,attr"`
+	Num          int     `xml:"number,attr"`
+	ThrowingHand string  `xml:"throwinghand,attr"`
+	ERA          float32 `xml:"era,attr"`
+	Wins         int     `xml:"wins,attr"`
+	Losses       int     `xml:"losses,attr"`
+	Saves        int     `xml:"saves,attr"`
+}
+
+// Broadcast represents the TV and radio broadcasters for each team.
+type Broadcast struct {
+	Away BroadcastOutlets `xml:"away"`
+	Home BroadcastOutlets `xml:"home"`
+}
+
+// BroadcastOutlets represents the TV and radio broadcasters for one team.
+type BroadcastOutlets struct {
+	TV    string `xml:"tv"`
+	Radio string `xml:"radio"`
+}
@@ -43,6 +43,8 @@ func TestLineScore(t *testing.T) {
 			"KC", "MIN", "Kansas City", "Minnesota", "Royals", "Twins",
 			"Y", "In Progress", 7, 0,
 			5, 4, 10, 9, 0, 1, 0,
+			1, "N", "2016/09/05", "",
+			"CT", "CT", "2016/09/05 2:10", "PM", -4,
 		},
 		"N", "N",
 		Review{0, 1, 0, 1},
@@ -151,11 +153,11 @@ func TestPlayers(t *testing.T) {
 				[]Player{
 					{
 						521692, "Salvador", "Perez", 13, "R", "R", "C", "A",
-						0.254, 20, 58, 0, 0, 0,
+						0.254, 20, 58, 0, 0, 0, 5, "C",
 					},
 					{
 						572044, "Brooks", "Pounders", 62, "R", "R", "P", "A",
-						0.000, 0, 0, 1, 1, 10.29,
+						0.000, 0, 0, 1, 1, 10.29, 0, "",
 					},
 				},
 				[]Coach{{124681, "Ned", "Yost", 3, "manager"}},
@@ -165,11 +167,11 @@ func TestPlayers(t *testing.T) {
 				[]Player{
 					{
 						542953, "Buddy", "Boshers", 62, "L", "L", "P", "A",
-						0.000, 0, 0, 2, 0, 5.33,
+						0.000, 0, 0, 2, 0, 5.33, 0, "",
 					},
 					{
 						621439, "Byron", "Buxton", 25, "R", "R", "CF", "A",
-						0.221, 4, 25, 0, 0, 0,
+						0.221, 4, 25, 0, 0, 0, 9, "CF",
 					},
 				},
 				[]Coach{{119236, "Paul", "Molitor", 4, "manager"}},
@@ -273,23 +275,28 @@ func TestCurrentAtBat(t *testing.T) {
 				"B", "2016-09-05T21:34:13Z", 134.08, 199.09, 91.5, 85.8, 3.1,
 				1.4, 8.41, 4.27, -0.448, 1.47, 1.261, 50.0, 5.548, -7.469,
 				-133.898, -6.314, 15.536, 24.104, -24.206, 23.9, -29.4, 6.3,
-				"SI", 0.887, 7, 62, 117.153, 1891.802,
+				"SI", 0.887, 7, 62, 117.153, 1891.802, 731,
 			},
 			{
 				"Foul",
 				"S", "2016-09-05T21:34:30Z", 100.57, 194.85, 92.8, 86.5, 3.1,
 				1.4, 9.53, 1.2, 0.431, 1.627, 1.411, 50.0, 5.55, -5.981,
 				-135.904, -5.034, 17.999, 27.035, -29.826, 23.9, -28.7, 7.5,
-				"SI", 0.896, 9, 51, 97.433, 1937.682,
+				"SI", 0.896, 9, 51, 97.433, 1937.682, 732,
 			},
 			{
 				"In play, out(s)",
 				"X", "2016-09-05T21:35:11Z", 127.52, 179.03, 93.7, 88.0, 3.1,
 				1.4, 9.37, 2.62, -0.276, 2.213, 1.298, 50.0, 5.639, -7.636,
 				-137.19, -4.417, 18.233, 24.366, -27.009, 23.9, -31.4, 6.7,
-				"SI", 0.902, 4, 34, 105.817, 2004.007,
+				"SI", 0.902, 4, 34, 105.817, 2004.007, 733,
 			},
 		},
+		736, "R", "L",
+		"Eduardo Escobar grounds into a double play, shortstop Alcides " +
+			"Escobar to second baseman Whit Merrifield to first baseman " +
+			"Eric Hosmer.   Eddie Rosario out at 2nd.  ",
+		[]Runner{{592696, "1B", "", "Grounded Into DP", 736, "", "", ""}},
 	}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("Game.CurrentAtBat returned %v, want %v", got, want)
@@ -333,9 +340,12 @@ func TestCurrentAtBatTopInning(t *testing.T) {
 				"S", "2016-09-05T18:10:36Z", 151.27, 180.81, 92.5, 85.4, 3.47,
 				1.62, -0.98, 9.59, -0.899, 2.147, -1.76, 50.0, 5.492, 2.649,
 				-135.539, -6.307, -1.831, 28.902, -14.218, 23.8, 4.7, 3.4,
-				"FF", 0.914, 13, 72, 185.821, 1931.941,
+				"FF", 0.914, 13, 72, 185.821, 1931.941, 3,
 			},
 		},
+		6, "L", "R",
+		"Jarrod Dyson singles on a line drive to right fielder Logan Schafer.  ",
+		nil,
 	}
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("Game.CurrentAtBat returned %v, want %v", got, want)
@@ -635,3 +645,295 @@ func TestNotificationsErrorEOF(t *testing.T) {
 
 	testError(t, "Game.Notifications", "EOF", err)
 }
+
+func TestBatter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data, err := ioutil.ReadFile("./mock/batter.xml")
+	if err != nil {
+		t.Fatal("Could not read data file")
+	}
+
+	gid := "2016_09_05_kcamlb_minmlb_1"
+	path := "/components/game/mlb/year_2016/month_09/day_05/" +
+		"gid_" + gid + "/batters/521692.xml"
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, string(data))
+	})
+
+	gameday := setupGameday()
+	game, err := gameday.Game(gid)
+	if err != nil {
+		t.Fatalf("Games.ByGID returned error: %v", err)
+	}
+
+	got, err := game.Batter(521692)
+	if err != nil {
+		t.Fatalf("Game.Batter returned error: %v", err)
+	}
+
+	want := &Batter{
+		ID: 521692, Team: "kc", Position: "C",
+		First: "Salvador", Last: "Perez", Num: 13, Bats: "R", Throws: "R",
+		Season:    BattingStats{"", 0.254, 452, 115, 20, 58, 20, 104},
+		Career:    BattingStats{"", 0.274, 2623, 719, 90, 346, 86, 392},
+		Month:     BattingStats{"September", 0.250, 16, 4, 1, 3, 0, 4},
+		Opponent:  BattingStats{"vs. MIN", 0.333, 48, 16, 3, 9, 2, 9},
+		Empty:     BattingStats{"Bases Empty", 0.238, 248, 59, 11, 11, 8, 60},
+		MenOn:     BattingStats{"Men On", 0.275, 204, 56, 9, 47, 12, 44},
+		RISP:      BattingStats{"RISP", 0.265, 117, 31, 5, 37, 9, 26},
+		Loaded:    BattingStats{"Bases Loaded", 0.300, 10, 3, 1, 12, 0, 2},
+		VsLHP:     BattingStats{"vs LHP", 0.262, 103, 27, 6, 16, 6, 22},
+		VsRHP:     BattingStats{"vs RHP", 0.252, 349, 88, 14, 42, 14, 82},
+		VsPitcher: BattingStats{"vs. Jose Berrios", 0.500, 2, 1, 0, 1, 0, 0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Game.Batter returned %v, want %v", got, want)
+	}
+}
+
+func TestBatterErrorHTTP404(t *testing.T) {
+	setup()
+	defer teardown()
+
+	gid := "2016_09_05_kcamlb_minmlb_1"
+	path := "/components/game/mlb/year_2016/month_09/day_05/" +
+		"gid_" + gid + "/batters/521692.xml"
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		http.Error(w, "Not Found", 404)
+	})
+
+	gameday := setupGameday()
+	game, err := gameday.Game(gid)
+	if err != nil {
+		t.Fatalf("Games.ByGID returned error: %v", err)
+	}
+
+	got, err := game.Batter(521692)
+	if got != nil {
+		t.Errorf("Game.Batter returned %v, want nil", got)
+	}
+
+	testError(t, "Game.Batter", "HTTP 404", err)
+}
+
+func TestPitcher(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data, err := ioutil.ReadFile("./mock/pitcher.xml")
+	if err != nil {
+		t.Fatal("Could not read data file")
+	}
+
+	gid := "2016_09_05_kcamlb_minmlb_1"
+	path := "/components/game/mlb/year_2016/month_09/day_05/" +
+		"gid_" + gid + "/pitchers/572044.xml"
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, string(data))
+	})
+
+	gameday := setupGameday()
+	game, err := gameday.Game(gid)
+	if err != nil {
+		t.Fatalf("Games.ByGID returned error: %v", err)
+	}
+
+	got, err := game.Pitcher(572044)
+	if err != nil {
+		t.Fatalf("Game.Pitcher returned error: %v", err)
+	}
+
+	want := &Pitcher{
+		ID: 572044, Team: "kc", Position: "P",
+		First: "Brooks", Last: "Pounders", Num: 62, Throws: "R",
+		Season: PitchingStats{
+			"", 0.333, 1, 1, 10.29, 6, 1, 0, 14.0, 22, 6, 12, 5, 2.00,
+		},
+		Career: PitchingStats{
+			"", 0.333, 1, 1, 10.29, 6, 1, 0, 14.0, 22, 6, 12, 5, 2.00,
+		},
+		Month: PitchingStats{
+			"September", 0.250, 0, 0, 4.50, 2, 0, 0, 2.0, 2, 1, 2, 0, 1.50,
+		},
+		Opponent: PitchingStats{
+			"vs. MIN", 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		},
+		Empty:    PitchingStats{Des: "Bases Empty", Avg: 0.310, H: 9, BB: 2, SO: 6, HR: 3},
+		MenOn:    PitchingStats{Des: "Men On", Avg: 0.361, H: 13, BB: 4, SO: 6, HR: 2},
+		RISP:     PitchingStats{Des: "RISP", Avg: 0.348, H: 8, BB: 3, SO: 4, HR: 1},
+		Loaded:   PitchingStats{Des: "Bases Loaded", Avg: 0.500, H: 2, SO: 1},
+		VsLHB:    PitchingStats{Des: "vs LHB", Avg: 0.391, H: 9, BB: 3, SO: 3, HR: 3},
+		VsRHB:    PitchingStats{Des: "vs RHB", Avg: 0.310, H: 13, BB: 3, SO: 9, HR: 2},
+		VsBatter: PitchingStats{Des: "vs. Kurt Suzuki"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Game.Pitcher returned %v, want %v", got, want)
+	}
+}
+
+func TestPitcherErrorHTTP404(t *testing.T) {
+	setup()
+	defer teardown()
+
+	gid := "2016_09_05_kcamlb_minmlb_1"
+	path := "/components/game/mlb/year_2016/month_09/day_05/" +
+		"gid_" + gid + "/pitchers/572044.xml"
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		http.Error(w, "Not Found", 404)
+	})
+
+	gameday := setupGameday()
+	game, err := gameday.Game(gid)
+	if err != nil {
+		t.Fatalf("Games.ByGID returned error: %v", err)
+	}
+
+	got, err := game.Pitcher(572044)
+	if got != nil {
+		t.Errorf("Game.Pitcher returned %v, want nil", got)
+	}
+
+	testError(t, "Game.Pitcher", "HTTP 404", err)
+}
+
+func TestListResources(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data, err := ioutil.ReadFile("./mock/game_listing.html")
+	if err != nil {
+		t.Fatal("Could not read data file")
+	}
+
+	gid := "2016_09_05_kcamlb_minmlb_1"
+	path := "/components/game/mlb/year_2016/month_09/day_05/" +
+		"gid_" + gid + "/"
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, string(data))
+	})
+
+	gameday := setupGameday()
+	game, err := gameday.Game(gid)
+	if err != nil {
+		t.Fatalf("Games.ByGID returned error: %v", err)
+	}
+
+	got, err := game.ListResources()
+	if err != nil {
+		t.Fatalf("Game.ListResources returned error: %v", err)
+	}
+
+	want := []string{
+		"batters/", "boxscore.xml", "game.xml", "inning/", "linescore.json",
+		"linescore.xml", "notifications/", "pitchers/", "players.xml",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Game.ListResources returned %v, want %v", got, want)
+	}
+}
+
+func TestListResourcesErrorHTTP404(t *testing.T) {
+	setup()
+	defer teardown()
+
+	gid := "2016_09_05_kcamlb_minmlb_1"
+	path := "/components/game/mlb/year_2016/month_09/day_05/" +
+		"gid_" + gid + "/"
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		http.Error(w, "Not Found", 404)
+	})
+
+	gameday := setupGameday()
+	game, err := gameday.Game(gid)
+	if err != nil {
+		t.Fatalf("Games.ByGID returned error: %v", err)
+	}
+
+	got, err := game.ListResources()
+	if got != nil {
+		t.Errorf("Game.ListResources returned %v, want nil", got)
+	}
+
+	testError(t, "Game.ListResources", "HTTP 404", err)
+}
+
+func TestLineScoreSportFromGID(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data, err := ioutil.ReadFile("./mock/linescore.xml")
+	if err != nil {
+		t.Fatal("Could not read data file")
+	}
+
+	gid := "2016_08_20_omaaaa_iowaaa_2"
+	path := "/components/game/aaa/year_2016/month_08/day_20/" +
+		"gid_" + gid + "/linescore.xml"
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, string(data))
+	})
+
+	gameday := setupGameday()
+	game, err := gameday.Game(gid)
+	if err != nil {
+		t.Fatalf("Games.ByGID returned error: %v", err)
+	}
+
+	if _, err := game.LineScore(); err != nil {
+		t.Fatalf("Game.LineScore returned error: %v", err)
+	}
+}
+
+func TestHitChart(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data, err := ioutil.ReadFile("./mock/inning_hit.xml")
+	if err != nil {
+		t.Fatal("Could not read data file")
+	}
+
+	gid := "2016_09_05_kcamlb_minmlb_1"
+	path := "/components/game/mlb/year_2016/month_09/day_05/" +
+		"gid_" + gid + "/inning/inning_hit.xml"
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, string(data))
+	})
+
+	gameday := setupGameday()
+	game, err := gameday.Game(gid)
+	if err != nil {
+		t.Fatalf("Games.ByGID returned error: %v", err)
+	}
+
+	got, err := game.HitChart()
+	if err != nil {
+		t.Fatalf("Game.HitChart returned error: %v", err)
+	}
+
+	if len(got.Hits) != 60 {
+		t.Fatalf("Game.HitChart returned %v hits, want 60", len(got.Hits))
+	}
+	want := HitLocation{"Single", 103.56, 152.96, 502481, 621244, "H", "A", 1}
+	if got.Hits[0] != want {
+		t.Errorf("Game.HitChart returned %+v, want %+v", got.Hits[0], want)
+	}
+}
@@ -0,0 +1,163 @@
+package mlbgameday
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	// The tz database is embedded so that dates can be converted to ET on
+	// systems without one installed.
+	_ "time/tzdata"
+)
+
+// eastern is the time zone in which the MLB Gameday API organizes its data.
+var eastern = mustLoadLocation("America/New_York")
+
+// mustLoadLocation returns the time zone with the provided IANA name. It
+// panics if the name is not in the tz database.
+func mustLoadLocation(name string) *time.Location {
+	l, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// GameDate is a day of MLB Gameday data. The MLB Gameday API organizes games
+// by their date in ET, so a game that starts at 10:10 PM PT on September 5
+// belongs to September 5 even though it starts on September 6 UTC.
+type GameDate struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// NewGameDate returns the GameDate for the provided year, month and day,
+// normalized as by time.Date.
+func NewGameDate(year int, month time.Month, day int) GameDate {
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, eastern))
+}
+
+// DateOf returns the GameDate on which the provided time falls in ET.
+func DateOf(t time.Time) GameDate {
+	y, m, d := t.In(eastern).Date()
+	return GameDate{y, m, d}
+}
+
+// ParseGameDate parses a date formatted as YYYY/MM/DD, as used by the MLB
+// Gameday API, or as YYYY-MM-DD, as used by the MLB Stats API.
+func ParseGameDate(s string) (GameDate, error) {
+	t, err := time.ParseInLocation("2006/01/02", strings.Replace(s, "-", "/", -1), eastern)
+	if err != nil {
+		return GameDate{}, errors.New("Invalid game date " + s)
+	}
+
+	return DateOf(t), nil
+}
+
+// Time returns midnight ET at the start of the date.
+func (d GameDate) Time() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, eastern)
+}
+
+// AddDays returns the date n days after d. n may be negative.
+func (d GameDate) AddDays(n int) GameDate {
+	return NewGameDate(d.Year, d.Month, d.Day+n)
+}
+
+// Before returns whether d is earlier than o.
+func (d GameDate) Before(o GameDate) bool {
+	return d.Time().Before(o.Time())
+}
+
+// After returns whether d is later than o.
+func (d GameDate) After(o GameDate) bool {
+	return d.Time().After(o.Time())
+}
+
+// String returns the date formatted as YYYY/MM/DD.
+func (d GameDate) String() string {
+	return d.Time().Format("2006/01/02")
+}
+
+// path returns the URL path to the Gameday data of the provided sport for
+// this date.
+func (d GameDate) path(sport Sport) string {
+	return sport.path() + d.Time().Format("year_2006/month_01/day_02/")
+}
+
+// timeZones maps the time zone abbreviations used by the MLB Gameday API to
+// locations in the tz database.
+var timeZones = map[string]string{
+	"ET":  "America/New_York",
+	"CT":  "America/Chicago",
+	"MT":  "America/Denver",
+	"MST": "America/Phoenix",
+	"PT":  "America/Los_Angeles",
+	"AST": "America/Puerto_Rico",
+	"HST": "Pacific/Honolulu",
+}
+
+// timeZone returns the location of the provided Gameday time zone
+// abbreviation.
+func timeZone(abbrev string) (*time.Location, bool) {
+	name, ok := timeZones[strings.ToUpper(abbrev)]
+	if !ok {
+		return nil, false
+	}
+
+	l, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, false
+	}
+	return l, true
+}
+
+// StartTime returns the scheduled start time of the game in the provided
+// location. The start time is read from TimeDate in the game's TimeZone,
+// falling back to the home league's start time and UTC offset when the time
+// zone is not recognized.
+func (g *Game) StartTime(loc *time.Location) (time.Time, error) {
+	const layout = "2006/01/02 3:04 PM"
+
+	if l, ok := timeZone(g.TimeZone); ok && g.TimeDate != "" {
+		t, err := time.ParseInLocation(layout, g.TimeDate+" "+g.AMPM, l)
+		if err == nil {
+			return t.In(loc), nil
+		}
+	}
+
+	if g.HomeLeagueTimeDate != "" {
+		l := time.FixedZone(g.TimeZone, g.HomeLeagueTimeZone*60*60)
+		t, err := time.ParseInLocation(layout,
+			g.HomeLeagueTimeDate+" "+g.HomeLeagueAMPM, l)
+		if err == nil {
+			return t.In(loc), nil
+		}
+	}
+
+	return time.Time{}, errors.New("Could not derive start time of game " + g.GID)
+}
+
+// HomeStartTime returns the scheduled start time of the game in the home
+// team's time zone.
+func (g *Game) HomeStartTime() (time.Time, error) {
+	return g.localStartTime(g.HomeTimeZone)
+}
+
+// AwayStartTime returns the scheduled start time of the game in the away
+// team's time zone.
+func (g *Game) AwayStartTime() (time.Time, error) {
+	return g.localStartTime(g.AwayTimeZone)
+}
+
+// localStartTime returns the start time of the game in the time zone with
+// the provided Gameday abbreviation.
+func (g *Game) localStartTime(abbrev string) (time.Time, error) {
+	l, ok := timeZone(abbrev)
+	if !ok {
+		return time.Time{}, errors.New("Unknown time zone " + abbrev)
+	}
+
+	return g.StartTime(l)
+}
@@ -0,0 +1,139 @@
+package mlbgameday
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDateOf(t *testing.T) {
+	var testCases = []struct {
+		Time time.Time
+		Want GameDate
+	}{
+		{time.Date(2016, 9, 6, 3, 30, 0, 0, time.UTC), GameDate{2016, 9, 5}},
+		{time.Date(2016, 9, 6, 4, 0, 0, 0, time.UTC), GameDate{2016, 9, 6}},
+		{time.Date(2016, 9, 5, 23, 0, 0, 0, mustLoadLocation("America/Los_Angeles")),
+			GameDate{2016, 9, 6}},
+	}
+
+	for _, tc := range testCases {
+		if got := DateOf(tc.Time); got != tc.Want {
+			t.Errorf("DateOf(%v) == %v, want %v", tc.Time, got, tc.Want)
+		}
+	}
+}
+
+func TestGameDate(t *testing.T) {
+	d := NewGameDate(2016, 9, 30)
+	if got, want := d.AddDays(1), (GameDate{2016, 10, 1}); got != want {
+		t.Errorf("GameDate.AddDays(1) == %v, want %v", got, want)
+	}
+	if got, want := d.AddDays(-30), (GameDate{2016, 8, 31}); got != want {
+		t.Errorf("GameDate.AddDays(-30) == %v, want %v", got, want)
+	}
+	if !d.Before(d.AddDays(1)) || d.After(d.AddDays(1)) {
+		t.Errorf("GameDate.Before/After do not order %v before %v", d, d.AddDays(1))
+	}
+
+	want := time.Date(2016, 9, 30, 4, 0, 0, 0, time.UTC)
+	if got := d.Time(); !got.Equal(want) {
+		t.Errorf("GameDate.Time() == %v, want %v", got, want)
+	}
+	if got := d.String(); got != "2016/09/30" {
+		t.Errorf("GameDate.String() == %q, want %q", got, "2016/09/30")
+	}
+	if got := d.path(AAA); got != "components/game/aaa/year_2016/month_09/day_30/" {
+		t.Errorf("GameDate.path(AAA) == %q", got)
+	}
+}
+
+func TestParseGameDate(t *testing.T) {
+	for _, s := range []string{"2016/09/05", "2016-09-05"} {
+		got, err := ParseGameDate(s)
+		if err != nil {
+			t.Fatalf("ParseGameDate(%q) returned error: %v", s, err)
+		}
+		if want := (GameDate{2016, 9, 5}); got != want {
+			t.Errorf("ParseGameDate(%q) == %v, want %v", s, got, want)
+		}
+	}
+
+	_, err := ParseGameDate("2016/09")
+	testError(t, "ParseGameDate", "Invalid game date 2016/09", err)
+}
+
+func TestScoreboardLateNightUTC(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data, err := ioutil.ReadFile("./mock/miniscoreboard.xml")
+	if err != nil {
+		t.Fatal("Could not read data file")
+	}
+
+	path := "/components/game/mlb/year_2016/month_09/day_05/miniscoreboard.xml"
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, string(data))
+	})
+
+	// 11:30 PM ET on September 5.
+	gameday := client.Gameday(time.Date(2016, 9, 6, 3, 30, 0, 0, time.UTC))
+	if _, err := gameday.Scoreboard(); err != nil {
+		t.Fatalf("Gameday.Scoreboard returned error: %v", err)
+	}
+}
+
+func TestGameStartTime(t *testing.T) {
+	g := Game{
+		GID:      "2016_09_05_kcamlb_minmlb_1",
+		TimeDate: "2016/09/05 2:10", TimeZone: "ET", AMPM: "PM",
+		AwayTimeZone: "CT", HomeTimeZone: "CT",
+	}
+
+	got, err := g.StartTime(time.UTC)
+	if err != nil {
+		t.Fatalf("Game.StartTime returned error: %v", err)
+	}
+	want := time.Date(2016, 9, 5, 18, 10, 0, 0, time.UTC)
+	if !got.Equal(want) || got.Location() != time.UTC {
+		t.Errorf("Game.StartTime(UTC) == %v, want %v", got, want)
+	}
+
+	home, err := g.HomeStartTime()
+	if err != nil {
+		t.Fatalf("Game.HomeStartTime returned error: %v", err)
+	}
+	if got := home.Format("3:04 PM MST"); got != "1:10 PM CDT" {
+		t.Errorf("Game.HomeStartTime() == %v, want 1:10 PM CDT", got)
+	}
+
+	g.AwayTimeZone = "XT"
+	_, err = g.AwayStartTime()
+	testError(t, "Game.AwayStartTime", "Unknown time zone XT", err)
+}
+
+func TestGameStartTimeHomeLeague(t *testing.T) {
+	g := Game{
+		GID:      "2016_09_05_kcamlb_minmlb_1",
+		TimeDate: "2016/09/05 2:10", TimeZone: "XT", AMPM: "PM",
+		HomeLeagueTimeDate: "2016/09/05 2:10", HomeLeagueAMPM: "PM",
+		HomeLeagueTimeZone: -4,
+	}
+
+	got, err := g.StartTime(time.UTC)
+	if err != nil {
+		t.Fatalf("Game.StartTime returned error: %v", err)
+	}
+	if want := time.Date(2016, 9, 5, 18, 10, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Game.StartTime(UTC) == %v, want %v", got, want)
+	}
+
+	g.HomeLeagueTimeDate = ""
+	_, err = g.StartTime(time.UTC)
+	testError(t, "Game.StartTime",
+		"Could not derive start time of game 2016_09_05_kcamlb_minmlb_1", err)
+}
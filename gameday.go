@@ -1,8 +1,7 @@
 package mlbgameday
 
 import (
-	"encoding/xml"
-	"fmt"
+	"strings"
 	"time"
 )
 
@@ -10,8 +9,12 @@ import (
 // Gameday API.
 type GamedayService interface {
 	Scoreboard() (*Scoreboard, error)
+	MasterScoreboard() (*MasterScoreboard, error)
 	GamesInProgress() ([]Game, error)
 	Game(string) (GameService, error)
+	ListGIDs() ([]string, error)
+	GamesForTeam(string) ([]Game, error)
+	GameForTeam(string) ([]GameService, error)
 }
 
 // GamedayServiceOp communicates with the MLB Gameday API to access a game.
@@ -22,21 +25,31 @@ type GamedayServiceOp struct {
 	// The date with which this service is associated.
 	date time.Time
 
+	// The sport with which this service is associated.
+	sport Sport
+
 	// The path to the MLB Gameday API at which resources for this day reside.
 	path string
 }
 
-// NewGamedayService returns a new GamedayServiceOp for the provided date.
-// Communication with the MLB Gameday API occurs through the provided Client.
-// The provided date will be converted to ET to be consistent with the
-// Gameday API.
+// NewGamedayService returns a new GamedayServiceOp for MLB games on the
+// provided date. Communication with the MLB Gameday API occurs through the
+// provided Client. The provided date will be converted to ET to be
+// consistent with the Gameday API; see GameDate.
 func NewGamedayService(client *Client, date time.Time) GamedayService {
-	l, _ := time.LoadLocation("America/New_York")
+	return NewSportGamedayService(client, MLB, date)
+}
+
+// NewSportGamedayService returns a new GamedayServiceOp for games of the
+// provided sport on the provided date. See NewGamedayService.
+func NewSportGamedayService(client *Client, sport Sport, date time.Time) GamedayService {
+	d := DateOf(date)
 
 	return &GamedayServiceOp{
 		client: client,
-		date:   date.In(l),
-		path:   pathFromDate(date),
+		date:   d.Time(),
+		sport:  sport,
+		path:   d.path(sport),
 	}
 }
 
@@ -55,6 +68,27 @@ func (s *GamedayServiceOp) Scoreboard() (*Scoreboard, error) {
 	return sb, nil
 }
 
+// MasterScoreboard represents the full list of MLB games for a day, including
+// probable and decision pitchers, home runs and broadcast information.
+type MasterScoreboard struct {
+	Games []MasterGame `xml:"game"`
+}
+
+// MasterScoreboard lists all the games scheduled on the provided date along
+// with the detail available on the day's master scoreboard.
+func (s *GamedayServiceOp) MasterScoreboard() (*MasterScoreboard, error) {
+	sb := new(MasterScoreboard)
+	if err := s.client.getResource(s.path+"master_scoreboard", sb); err != nil {
+		return nil, err
+	}
+
+	for i := range sb.Games {
+		sb.Games[i].fill()
+	}
+
+	return sb, nil
+}
+
 // GamesInProgress lists all the games for the current day that are in progress.
 func (s *GamedayServiceOp) GamesInProgress() ([]Game, error) {
 	sb, err := s.getScoreboard()
@@ -62,43 +96,168 @@ func (s *GamedayServiceOp) GamesInProgress() ([]Game, error) {
 		return nil, err
 	}
 
-	var games []Game
-	for _, game := range sb.Games {
-		if game.Status == "In Progress" {
-			games = append(games, game)
+	return gamesInProgress(sb), nil
+}
+
+// Game returns a new GameServiceOp linked to the game referenced by the
+// provided game ID (gid). The game's sport is inferred from the gid when
+// possible and is otherwise the sport of this service. A game whose gid is
+// of another date, such as a suspended game resumed on this date, is looked
+// up on this date's scoreboard so that its service covers every part of the
+// game, as with GameForTeam.
+func (s *GamedayServiceOp) Game(gid string) (GameService, error) {
+	if d, ok := dateFromGID(gid); ok && d != DateOf(s.date) {
+		sb, err := s.getScoreboard()
+		if e, ok := err.(*HTTPError); ok && e.StatusCode == 404 {
+			return s.newGameService(gid)
+		}
+		if err != nil {
+			return nil, err
+		}
+		for i := range sb.Games {
+			if sb.Games[i].GID == gid {
+				return s.gameService(&sb.Games[i])
+			}
 		}
 	}
 
-	return games, nil
+	return s.newGameService(gid)
 }
 
-// Game returns a new GameServiceOp linked to the game referenced by the
-// provided game ID (gid).
-func (s *GamedayServiceOp) Game(gid string) (GameService, error) {
-	svc, err := NewGameService(s.client, gid)
+// gameService returns a GameService for a game on this date's scoreboard.
+// The service for a game that was suspended and resumed on another date
+// covers both parts of the game.
+func (s *GamedayServiceOp) gameService(g *Game) (GameService, error) {
+	if g.IsResumed() {
+		return newResumedGameService(s.client, s.sportOf(g.GID), g.GID, s.date)
+	}
+	if resumed, ok := g.Resumption(); ok {
+		return newResumedGameService(s.client, s.sportOf(g.GID), g.GID, resumed)
+	}
+
+	return s.newGameService(g.GID)
+}
+
+// newGameService returns a new GameServiceOp for the game folder of the
+// provided game ID.
+func (s *GamedayServiceOp) newGameService(gid string) (GameService, error) {
+	return NewSportGameService(s.client, s.sportOf(gid), gid)
+}
+
+// sportOf returns the sport of the game referenced by the provided game ID,
+// or the sport of this service if it cannot be inferred.
+func (s *GamedayServiceOp) sportOf(gid string) Sport {
+	if sport, ok := SportFromGID(gid); ok {
+		return sport
+	}
+	return s.sport
+}
+
+// GamesForTeam lists the games scheduled on the provided date for the team
+// referred to by the provided key, which may be any key accepted by
+// LookupFranchise.
+func (s *GamedayServiceOp) GamesForTeam(team string) ([]Game, error) {
+	sb, err := s.getScoreboard()
 	if err != nil {
 		return nil, err
 	}
-	return svc, err
+
+	return sb.GamesForTeam(team), nil
 }
 
-// getScoreboard retrieves all the games on the provided date.
-func (s *GamedayServiceOp) getScoreboard() (*Scoreboard, error) {
-	data, err := s.client.get(fmt.Sprintf("%s/miniscoreboard.xml", s.path))
+// GameForTeam returns a GameService for each game scheduled on the provided
+// date for the team referred to by the provided key. It returns two services
+// when the team plays a doubleheader. The service for a game that was
+// suspended and resumed on another date covers both parts of the game.
+func (s *GamedayServiceOp) GameForTeam(team string) ([]GameService, error) {
+	games, err := s.GamesForTeam(team)
 	if err != nil {
 		return nil, err
 	}
 
-	sb := new(Scoreboard)
-	err = xml.Unmarshal(data, &sb)
+	var svcs []GameService
+	for i := range games {
+		svc, err := s.gameService(&games[i])
+		if err != nil {
+			return nil, err
+		}
+		svcs = append(svcs, svc)
+	}
+
+	return svcs, nil
+}
+
+// ListGIDs lists the game IDs of every game folder published for this day,
+// including games that do not appear on the scoreboard.
+func (s *GamedayServiceOp) ListGIDs() ([]string, error) {
+	data, err := s.client.get(s.path)
 	if err != nil {
 		return nil, err
 	}
 
+	var gids []string
+	for _, name := range parseListing(data) {
+		if strings.HasPrefix(name, "gid_") && strings.HasSuffix(name, "/") {
+			gids = append(gids, strings.TrimSuffix(name[4:], "/"))
+		}
+	}
+
+	return gids, nil
+}
+
+// getScoreboard retrieves all the games on the provided date.
+func (s *GamedayServiceOp) getScoreboard() (*Scoreboard, error) {
+	sb := new(Scoreboard)
+	if err := s.client.getResource(s.path+"miniscoreboard", sb); err != nil {
+		return nil, err
+	}
+
 	return sb, nil
 }
 
-// pathFromDate returns the URL path to all Gameday data for a given date.
-func pathFromDate(date time.Time) string {
-	return date.Format("components/game/mlb/year_2006/month_01/day_02/")
+// fill copies the game's status and line score totals into its embedded
+// Game.
+func (g *MasterGame) fill() {
+	g.Status = g.GameStatus.Status
+	g.TopInning = g.GameStatus.TopInning
+	g.Inning = g.GameStatus.Inning
+	g.Outs = g.GameStatus.Outs
+	g.AwayTeamRuns = g.LineScore.Runs.Away
+	g.HomeTeamRuns = g.LineScore.Runs.Home
+	g.AwayHitsRuns = g.LineScore.Hits.Away
+	g.HomeHitsRuns = g.LineScore.Hits.Home
+	g.AwayTeamErrors = g.LineScore.Errors.Away
+	g.HomeTeamErrors = g.LineScore.Errors.Home
+}
+
+// gameForTeam returns a GameService for each of the team's games on the
+// scoreboard of s.
+func gameForTeam(s GamedayService, team string) ([]GameService, error) {
+	games, err := s.GamesForTeam(team)
+	if err != nil {
+		return nil, err
+	}
+
+	var svcs []GameService
+	for _, g := range games {
+		svc, err := s.Game(g.GID)
+		if err != nil {
+			return nil, err
+		}
+		svcs = append(svcs, svc)
+	}
+
+	return svcs, nil
+}
+
+// gamesInProgress returns the games on the scoreboard that are in progress.
+func gamesInProgress(sb *Scoreboard) []Game {
+	var games []Game
+	for _, game := range sb.Games {
+		if game.Status == "In Progress" {
+			games = append(games, game)
+		}
+	}
+
+	return games
 }
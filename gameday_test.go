@@ -68,6 +68,8 @@ func TestScoreboard(t *testing.T) {
 				"TOR", "NYY", "Toronto", "NY Yankees", "Blue Jays", "Yankees",
 				"Y", "In Progress", 1, 0,
 				0, 0, 1, 0, 0, 0, 2,
+				1, "N", "2016/09/05", "",
+				"ET", "ET", "2016/09/05 1:05", "PM", -4,
 			},
 			{
 				"2016_09_05_nynmlb_cinmlb_1",
@@ -75,6 +77,8 @@ func TestScoreboard(t *testing.T) {
 				"NYM", "CIN", "NY Mets", "Cincinnati", "Mets", "Reds",
 				"", "Preview", 0, 0,
 				0, 0, 0, 0, 0, 0, 0,
+				1, "N", "2016/09/05", "",
+				"ET", "ET", "2016/09/05 1:10", "PM", -4,
 			},
 		},
 	}
@@ -149,6 +153,8 @@ func TestGamesInProgress(t *testing.T) {
 			"TOR", "NYY", "Toronto", "NY Yankees", "Blue Jays", "Yankees",
 			"Y", "In Progress", 1, 0,
 			0, 0, 1, 0, 0, 0, 2,
+			1, "N", "2016/09/05", "",
+			"ET", "ET", "2016/09/05 1:05", "PM", -4,
 		},
 	}
 	if !reflect.DeepEqual(got, want) {
@@ -177,3 +183,266 @@ func TestGamesInProgressErrorHTTP404(t *testing.T) {
 
 	testError(t, "Gameday.GamesInProgress", "HTTP 404", err)
 }
+
+func TestMasterScoreboard(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data, err := ioutil.ReadFile("./mock/master_scoreboard.xml")
+	if err != nil {
+		t.Fatal("Could not read data file")
+	}
+
+	path := "/components/game/mlb/year_2016/month_09/day_05/master_scoreboard.xml"
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, string(data))
+	})
+
+	gameday := setupGameday()
+
+	got, err := gameday.MasterScoreboard()
+	if err != nil {
+		t.Fatalf("Gameday.MasterScoreboard returned error: %v", err)
+	}
+
+	if len(got.Games) != 2 {
+		t.Fatalf("Gameday.MasterScoreboard returned %v games, want 2",
+			len(got.Games))
+	}
+
+	final := got.Games[0]
+	wantGame := Game{
+		"2016_09_05_kcamlb_minmlb_1",
+		"2016/09/05 2:10", "ET", "PM",
+		"KC", "MIN", "Kansas City", "Minnesota", "Royals", "Twins",
+		"N", "Final", 9, 3,
+		10, 6, 15, 11, 0, 1, 0,
+		1, "N", "2016/09/05", "",
+		"CT", "CT", "", "", 0,
+	}
+	if !reflect.DeepEqual(final.Game, wantGame) {
+		t.Errorf("MasterGame.Game is %v, want %v", final.Game, wantGame)
+	}
+
+	wantHR := []HomeRun{
+		{521692, "Salvador", "Perez", 13, "kca", 1, 20, 3, 1},
+		{621439, "Byron", "Buxton", 25, "min", 1, 5, 5, 2},
+	}
+	if !reflect.DeepEqual(final.HomeRuns, wantHR) {
+		t.Errorf("MasterGame.HomeRuns is %v, want %v", final.HomeRuns, wantHR)
+	}
+
+	wantW := ScoreboardPitcher{543169, "Brian", "Flynn", 32, "", 2.60, 2, 2, 0}
+	if final.WinningPitcher != wantW {
+		t.Errorf("MasterGame.WinningPitcher is %v, want %v",
+			final.WinningPitcher, wantW)
+	}
+
+	wantL := ScoreboardPitcher{592872, "Alex", "Wimmers", 57, "", 7.27, 0, 1, 0}
+	if final.LosingPitcher != wantL {
+		t.Errorf("MasterGame.LosingPitcher is %v, want %v",
+			final.LosingPitcher, wantL)
+	}
+
+	if final.SavePitcher.ID != 0 {
+		t.Errorf("MasterGame.SavePitcher.ID is %v, want 0",
+			final.SavePitcher.ID)
+	}
+
+	if n := len(final.LineScore.Innings); n != 9 {
+		t.Errorf("MasterGame.LineScore has %v innings, want 9", n)
+	}
+
+	preview := got.Games[1]
+	wantAway := ScoreboardPitcher{
+		592789, "Noah", "Syndergaard", 34, "RHP", 2.55, 13, 8, 0,
+	}
+	if preview.AwayProbablePitcher != wantAway {
+		t.Errorf("MasterGame.AwayProbablePitcher is %v, want %v",
+			preview.AwayProbablePitcher, wantAway)
+	}
+
+	wantHome := ScoreboardPitcher{
+		608566, "Cody", "Reed", 48, "LHP", 6.97, 0, 7, 0,
+	}
+	if preview.HomeProbablePitcher != wantHome {
+		t.Errorf("MasterGame.HomeProbablePitcher is %v, want %v",
+			preview.HomeProbablePitcher, wantHome)
+	}
+
+	wantBroadcast := Broadcast{
+		BroadcastOutlets{"SNY", "WOR 710"},
+		BroadcastOutlets{"FSOH", "WLW 700"},
+	}
+	if preview.Broadcast != wantBroadcast {
+		t.Errorf("MasterGame.Broadcast is %v, want %v",
+			preview.Broadcast, wantBroadcast)
+	}
+}
+
+func TestMasterScoreboardErrorHTTP404(t *testing.T) {
+	setup()
+	defer teardown()
+
+	path := "/components/game/mlb/year_2016/month_09/day_05/master_scoreboard.xml"
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		http.Error(w, "Not Found", 404)
+	})
+
+	gameday := setupGameday()
+
+	got, err := gameday.MasterScoreboard()
+	if got != nil {
+		t.Errorf("Gameday.MasterScoreboard returned %v, want nil", got)
+	}
+
+	testError(t, "Gameday.MasterScoreboard", "HTTP 404", err)
+}
+
+func TestListGIDs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data, err := ioutil.ReadFile("./mock/day_listing.html")
+	if err != nil {
+		t.Fatal("Could not read data file")
+	}
+
+	path := "/components/game/mlb/year_2016/month_09/day_05/"
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, string(data))
+	})
+
+	gameday := setupGameday()
+
+	got, err := gameday.ListGIDs()
+	if err != nil {
+		t.Fatalf("Gameday.ListGIDs returned error: %v", err)
+	}
+
+	want := []string{
+		"2016_09_05_kcamlb_minmlb_1",
+		"2016_09_05_nynmlb_cinmlb_1",
+		"2016_09_05_sdnmlb_colmlb_1",
+		"2016_09_05_tormlb_nyamlb_1",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Gameday.ListGIDs returned %v, want %v", got, want)
+	}
+}
+
+func TestListGIDsErrorHTTP404(t *testing.T) {
+	setup()
+	defer teardown()
+
+	path := "/components/game/mlb/year_2016/month_09/day_05/"
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		http.Error(w, "Not Found", 404)
+	})
+
+	gameday := setupGameday()
+
+	got, err := gameday.ListGIDs()
+	if got != nil {
+		t.Errorf("Gameday.ListGIDs returned %v, want nil", got)
+	}
+
+	testError(t, "Gameday.ListGIDs", "HTTP 404", err)
+}
+
+func TestSportGamedayScoreboard(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data, err := ioutil.ReadFile("./mock/miniscoreboard.xml")
+	if err != nil {
+		t.Fatal("Could not read data file")
+	}
+
+	path := "/components/game/aaa/year_2016/month_09/day_05/miniscoreboard.xml"
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, string(data))
+	})
+
+	l, _ := time.LoadLocation("America/New_York")
+	gameday := client.SportGameday(AAA, time.Date(2016, 9, 5, 0, 0, 0, 0, l))
+
+	got, err := gameday.Scoreboard()
+	if err != nil {
+		t.Fatalf("Gameday.Scoreboard returned error: %v", err)
+	}
+
+	if len(got.Games) != 2 {
+		t.Errorf("Gameday.Scoreboard returned %v games, want 2", len(got.Games))
+	}
+}
+
+func TestGamesForTeam(t *testing.T) {
+	setup()
+	defer teardown()
+
+	path := "/components/game/mlb/year_2016/month_09/day_05/miniscoreboard.xml"
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `<games>`+
+			`<game gameday_link="2016_09_05_kcamlb_minmlb_1" away_name_abbrev="KC" home_name_abbrev="MIN"/>`+
+			`<game gameday_link="2016_09_05_tormlb_nyamlb_1" away_name_abbrev="TOR" home_name_abbrev="NYY"/>`+
+			`<game gameday_link="2016_09_05_kcamlb_minmlb_2" away_name_abbrev="KC" home_name_abbrev="MIN"/>`+
+			`</games>`)
+	})
+
+	gameday := setupGameday()
+
+	for _, team := range []string{"KC", "kca", "118", "min"} {
+		got, err := gameday.GamesForTeam(team)
+		if err != nil {
+			t.Fatalf("Gameday.GamesForTeam returned error: %v", err)
+		}
+
+		if len(got) != 2 || got[0].GID != "2016_09_05_kcamlb_minmlb_1" ||
+			got[1].GID != "2016_09_05_kcamlb_minmlb_2" {
+			t.Errorf("Gameday.GamesForTeam(%q) returned %v, want both games "+
+				"of the doubleheader", team, got)
+		}
+	}
+
+	svcs, err := gameday.GameForTeam("NYY")
+	if err != nil {
+		t.Fatalf("Gameday.GameForTeam returned error: %v", err)
+	}
+	if len(svcs) != 1 {
+		t.Errorf("Gameday.GameForTeam returned %v services, want 1", len(svcs))
+	}
+}
+
+func TestGamesForTeamErrorHTTP404(t *testing.T) {
+	setup()
+	defer teardown()
+
+	path := "/components/game/mlb/year_2016/month_09/day_05/miniscoreboard.xml"
+
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		http.Error(w, "Not Found", 404)
+	})
+
+	gameday := setupGameday()
+
+	got, err := gameday.GameForTeam("KC")
+	if got != nil {
+		t.Errorf("Gameday.GameForTeam returned %v, want nil", got)
+	}
+
+	testError(t, "Gameday.GameForTeam", "HTTP 404", err)
+}
@@ -0,0 +1,3 @@
+module github.com/ericdreeves/mlbgameday
+
+go 1.15
@@ -0,0 +1,90 @@
+// Package testutil provides the fixtures and fake services shared by the
+// tests of the packages that analyze games, such as runexp and winexp.
+//
+// Fixtures are read from the mock directory at the root of the repository,
+// which holds the data of a single game.
+package testutil
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/ericdreeves/mlbgameday"
+)
+
+// ReadMock decodes the XML fixture with the provided name, e.g.
+// "inning_all.xml", into v.
+func ReadMock(t testing.TB, name string, v interface{}) {
+	t.Helper()
+
+	_, file, _, _ := runtime.Caller(0)
+	data, err := ioutil.ReadFile(filepath.Join(filepath.Dir(file), "..", "..", "mock", name))
+	if err != nil {
+		t.Fatal("Could not read data file")
+	}
+	if err := xml.Unmarshal(data, v); err != nil {
+		t.Fatalf("Could not decode data file: %v", err)
+	}
+}
+
+// AtBats returns the at-bats of the mock game.
+func AtBats(t testing.TB) *mlbgameday.AtBats {
+	t.Helper()
+
+	abs := new(mlbgameday.AtBats)
+	ReadMock(t, "inning_all.xml", abs)
+	return abs
+}
+
+// Game is a GameService that returns fixed players, at-bats and hit chart, or
+// Err if it is not nil. Its other methods panic.
+type Game struct {
+	mlbgameday.GameService
+
+	Roster *mlbgameday.Players
+	Plays  *mlbgameday.AtBats
+	Chart  *mlbgameday.HitChart
+	Err    error
+}
+
+// NewGame returns a Game with the players, at-bats and hit chart of the mock
+// game.
+func NewGame(t testing.TB) *Game {
+	t.Helper()
+
+	g := &Game{
+		Roster: new(mlbgameday.Players),
+		Plays:  AtBats(t),
+		Chart:  new(mlbgameday.HitChart),
+	}
+	ReadMock(t, "players.xml", g.Roster)
+	ReadMock(t, "inning_hit.xml", g.Chart)
+	return g
+}
+
+// Players returns the players of the game.
+func (g *Game) Players() (*mlbgameday.Players, error) {
+	if g.Err != nil {
+		return nil, g.Err
+	}
+	return g.Roster, nil
+}
+
+// AtBats returns the at-bats of the game.
+func (g *Game) AtBats() (*mlbgameday.AtBats, error) {
+	if g.Err != nil {
+		return nil, g.Err
+	}
+	return g.Plays, nil
+}
+
+// HitChart returns the hit chart of the game.
+func (g *Game) HitChart() (*mlbgameday.HitChart, error) {
+	if g.Err != nil {
+		return nil, g.Err
+	}
+	return g.Chart, nil
+}
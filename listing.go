@@ -0,0 +1,34 @@
+package mlbgameday
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// hrefPattern matches the target of each link on a directory index page.
+var hrefPattern = regexp.MustCompile(`(?i)<a\s+[^>]*href="([^"]*)"`)
+
+// parseListing returns the names of the entries on an HTML directory index
+// page served by the MLB Gameday API. Subdirectories keep their trailing
+// slash. Links to parent directories, sort orders and other hosts are
+// skipped.
+func parseListing(data []byte) []string {
+	var names []string
+	for _, m := range hrefPattern.FindAllSubmatch(data, -1) {
+		href := string(m[1])
+		if href == "" || strings.HasPrefix(href, "/") ||
+			strings.HasPrefix(href, "?") || strings.HasPrefix(href, "#") ||
+			strings.HasPrefix(href, "..") || strings.Contains(href, "://") {
+			continue
+		}
+
+		name, err := url.PathUnescape(href)
+		if err != nil {
+			continue
+		}
+		names = append(names, name)
+	}
+
+	return names
+}
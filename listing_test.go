@@ -0,0 +1,22 @@
+package mlbgameday
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseListing(t *testing.T) {
+	data := []byte(`<table>
+<tr><th><a href="?C=N;O=D">Name</a></th></tr>
+<tr><td><a href="../">Parent Directory</a></td></tr>
+<tr><td><a href="http://mlb.com/">MLB</a></td></tr>
+<tr><td><A HREF="inning/">inning/</A></td></tr>
+<tr><td><a class="file" href="game%20events.xml">game events.xml</a></td></tr>
+</table>`)
+
+	got := parseListing(data)
+	want := []string{"inning/", "game events.xml"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseListing returned %v, want %v", got, want)
+	}
+}
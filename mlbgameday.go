@@ -16,8 +16,28 @@ type Client struct {
 
 	// Base URL for API requests.
 	BaseURL *url.URL
+
+	// Format in which resources are retrieved. Defaults to XML. Ignored by
+	// the StatsAPI backend, which only serves JSON.
+	Format Format
+
+	// Backend identifies the service at BaseURL. Defaults to GD2.
+	Backend Backend
 }
 
+// Backend identifies the MLB data service with which a Client communicates.
+type Backend int
+
+const (
+	// GD2 is the original MLB Gameday API, organized as folders of files by
+	// day and game.
+	GD2 Backend = iota
+
+	// StatsAPI is the MLB Stats API, organized as JSON endpoints by game ID
+	// (gamePk).
+	StatsAPI
+)
+
 // NewClient returns a new MLB Gameday API client.
 func NewClient(client *http.Client) *Client {
 	if client == nil {
@@ -33,9 +53,43 @@ func NewClient(client *http.Client) *Client {
 	return c
 }
 
-// Gameday returns a new GamedayService for the provided date.
+// NewStatsAPIClient returns a new client that communicates with the MLB Stats
+// API. The GamedayService and GameService it provides return the same types
+// as those of a client returned by NewClient.
+func NewStatsAPIClient(client *http.Client) *Client {
+	c := NewClient(client)
+	c.BaseURL = &url.URL{
+		Scheme: "https",
+		Host:   "statsapi.mlb.com",
+	}
+	c.Backend = StatsAPI
+
+	return c
+}
+
+// Gameday returns a new GamedayService for MLB games on the provided date.
 func (c *Client) Gameday(date time.Time) GamedayService {
-	return NewGamedayService(c, date)
+	return c.SportGameday(MLB, date)
+}
+
+// SportGameday returns a new GamedayService for games of the provided sport,
+// e.g. a minor league level, on the provided date.
+func (c *Client) SportGameday(sport Sport, date time.Time) GamedayService {
+	if c.Backend == StatsAPI {
+		return newStatsGamedayService(c, sport, date)
+	}
+	return NewSportGamedayService(c, sport, date)
+}
+
+// getResource retrieves the resource at the requested path, given without
+// its file extension, in the client's Format and decodes it into v.
+func (c *Client) getResource(path string, v interface{}) error {
+	data, err := c.get(path + c.Format.ext())
+	if err != nil {
+		return err
+	}
+
+	return c.Format.unmarshal(data, v)
 }
 
 // get sends an HTTP GET to the MLB Gameday API at the requested path
@@ -51,8 +105,18 @@ func (c *Client) get(path string) ([]byte, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("HTTP %v", resp.StatusCode)
+		return nil, &HTTPError{StatusCode: resp.StatusCode}
 	}
 
 	return ioutil.ReadAll(resp.Body)
 }
+
+// HTTPError is returned when the MLB Gameday API responds with a status other
+// than 200 OK.
+type HTTPError struct {
+	StatusCode int
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP %v", e.StatusCode)
+}
@@ -0,0 +1,494 @@
+// Package pitchclass classifies pitches by clustering each pitcher's pitches
+// on their PitchF/X measurements.
+//
+// MLB's classifier assigns each pitch a PitchType with a TypeConfidence, and
+// leaves some pitches unclassified. A Classifier instead groups a pitcher's
+// pitches by speed, spin, movement and release point, so that every pitch
+// of the same kind gets the same type, and names each group after the types
+// the feed gave its pitches with confidence, or else after the kind of pitch
+// it resembles. Pitches whose type differs from the feed's are reported.
+package pitchclass
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ericdreeves/mlbgameday"
+)
+
+// Unknown is the type of pitches that were neither classified nor typed by
+// the feed.
+const Unknown = "UN"
+
+// Features represents the measurements of a pitch by which it is clustered.
+// Horizontal measurements are mirrored for left-handed pitchers, so that
+// positive values point to the pitcher's arm side.
+type Features struct {
+	// Speed is the StartSpeed, in miles per hour, and Spin the SpinRate, in
+	// revolutions per minute.
+	Speed float64
+	Spin  float64
+
+	// ArmSide and Rise are the horizontal and vertical movement, in inches,
+	// as measured by PfxX and PfxZ.
+	ArmSide float64
+	Rise    float64
+
+	// ReleaseSide and ReleaseHeight locate the release point, in feet.
+	ReleaseSide   float64
+	ReleaseHeight float64
+}
+
+// FeaturesOf returns the features of a pitch thrown in an at-bat. It returns
+// false if the pitch was not tracked.
+func FeaturesOf(ab *mlbgameday.AtBat, p *mlbgameday.Pitch) (Features, bool) {
+	if p.StartSpeed == 0 || p.VY0 == 0 {
+		return Features{}, false
+	}
+
+	side := -1.0
+	if ab.PThrows == "L" {
+		side = 1
+	}
+	release := p.Trajectory().Release()
+	return Features{
+		Speed:         float64(p.StartSpeed),
+		Spin:          float64(p.SpinRate),
+		ArmSide:       side * float64(p.PfxX),
+		Rise:          float64(p.PfxZ),
+		ReleaseSide:   side * release.X,
+		ReleaseHeight: release.Z,
+	}, true
+}
+
+// scales holds the difference in each feature, in the order of vector, that
+// counts as a unit of distance between pitches: about the least variation
+// between pitches of the same kind.
+var scales = [6]float64{1.5, 400, 2, 2, 0.5, 0.5}
+
+// vector returns the features scaled for clustering.
+func (f Features) vector() [6]float64 {
+	v := [6]float64{f.Speed, f.Spin, f.ArmSide, f.Rise, f.ReleaseSide, f.ReleaseHeight}
+	for i := range v {
+		v[i] /= scales[i]
+	}
+	return v
+}
+
+// features returns the features of a scaled vector.
+func features(v [6]float64) Features {
+	for i := range v {
+		v[i] *= scales[i]
+	}
+	return Features{v[0], v[1], v[2], v[3], v[4], v[5]}
+}
+
+// distance returns the squared distance between two scaled vectors.
+func distance(a, b [6]float64) float64 {
+	d := 0.0
+	for i := range a {
+		d += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return d
+}
+
+// Classification represents the type assigned to a pitch.
+type Classification struct {
+	AtBat *mlbgameday.AtBat
+	Pitch *mlbgameday.Pitch
+
+	// Pitcher is the ID of the pitcher, and Cluster the index of the
+	// pitch's cluster in his Arsenal.
+	Pitcher int
+	Cluster int
+
+	// Type is the assigned type, e.g. "FF", and FeedType the PitchType.
+	Type     string
+	FeedType string
+}
+
+// Disagrees returns whether the assigned type differs from the feed's,
+// including when the feed has no type.
+func (c *Classification) Disagrees() bool {
+	return c.Type != c.FeedType
+}
+
+// Cluster represents a group of similar pitches thrown by a pitcher.
+type Cluster struct {
+	Type   string
+	Count  int
+	Center Features
+}
+
+// Arsenal represents the clusters of a pitcher's pitches, most thrown first.
+type Arsenal struct {
+	Pitcher  int
+	PThrows  string
+	Clusters []Cluster
+}
+
+// Report represents the classification of the pitches of a set of games.
+type Report struct {
+	// Pitches holds the classification of every tracked pitch, grouped by
+	// pitcher in the order of Arsenals and in order of play within each.
+	Pitches []Classification
+
+	// Arsenals holds the arsenal of each pitcher, in the order in which
+	// they first pitched.
+	Arsenals []Arsenal
+
+	// index holds the position in Pitches of each pitch classified by
+	// Classify.
+	index map[*mlbgameday.Pitch]int
+}
+
+// TypeOf returns the type assigned to a pitch or, if the pitch was not
+// classified, as for untracked pitches, its PitchType, or Unknown if it has
+// none. Pitches are typed by their PitchType if the report is nil.
+func (r *Report) TypeOf(p *mlbgameday.Pitch) string {
+	if r != nil {
+		if i, ok := r.index[p]; ok {
+			return r.Pitches[i].Type
+		}
+		if r.index == nil {
+			for _, c := range r.Pitches {
+				if c.Pitch == p {
+					return c.Type
+				}
+			}
+		}
+	}
+
+	if p.PitchType != "" {
+		return p.PitchType
+	}
+	return Unknown
+}
+
+// Disagreements returns the pitches whose assigned type differs from the
+// feed's.
+func (r *Report) Disagreements() []Classification {
+	var cs []Classification
+	for _, c := range r.Pitches {
+		if c.Disagrees() {
+			cs = append(cs, c)
+		}
+	}
+	return cs
+}
+
+// Classifier classifies pitches by clustering each pitcher's pitches.
+type Classifier struct {
+	// MaxClusters is the largest number of kinds of pitch a pitcher is taken
+	// to throw, and MinPitches the fewest pitches of each kind.
+	MaxClusters int
+	MinPitches  int
+
+	// Separation is the least distance between the centers of two clusters,
+	// relative to the spread of their pitches.
+	Separation float64
+
+	// MinConfidence is the least TypeConfidence with which a pitch's
+	// PitchType names its cluster.
+	MinConfidence float32
+}
+
+// NewClassifier returns a new Classifier with default settings.
+func NewClassifier() *Classifier {
+	return &Classifier{
+		MaxClusters:   6,
+		MinPitches:    2,
+		Separation:    3.5,
+		MinConfidence: 0.8,
+	}
+}
+
+// pitch represents a tracked pitch during classification.
+type pitch struct {
+	ab     *mlbgameday.AtBat
+	p      *mlbgameday.Pitch
+	vector [6]float64
+}
+
+// Classify classifies the pitches of a set of games. Each pitcher's pitches
+// are clustered across all the games.
+func (c *Classifier) Classify(games ...*mlbgameday.AtBats) *Report {
+	var order []int
+	byPitcher := make(map[int][]pitch)
+	hands := make(map[int]string)
+	for _, abs := range games {
+		for i := range abs.Innings {
+			inning := &abs.Innings[i]
+			for _, half := range [][]mlbgameday.AtBat{inning.Top, inning.Bottom} {
+				for j := range half {
+					ab := &half[j]
+					for k := range ab.Pitches {
+						f, ok := FeaturesOf(ab, &ab.Pitches[k])
+						if !ok {
+							continue
+						}
+						if _, ok := byPitcher[ab.Pitcher]; !ok {
+							order = append(order, ab.Pitcher)
+							hands[ab.Pitcher] = ab.PThrows
+						}
+						byPitcher[ab.Pitcher] = append(byPitcher[ab.Pitcher],
+							pitch{ab, &ab.Pitches[k], f.vector()})
+					}
+				}
+			}
+		}
+	}
+
+	r := &Report{index: make(map[*mlbgameday.Pitch]int)}
+	for _, id := range order {
+		ps := byPitcher[id]
+		centers, assignment := c.cluster(ps)
+		arsenal := Arsenal{Pitcher: id, PThrows: hands[id]}
+		for _, center := range centers {
+			arsenal.Clusters = append(arsenal.Clusters, Cluster{Center: features(center)})
+		}
+		for _, a := range assignment {
+			arsenal.Clusters[a].Count++
+		}
+		c.name(arsenal.Clusters, ps, assignment)
+
+		// Order clusters by use, keeping track of their new indexes.
+		index := make([]int, len(arsenal.Clusters))
+		for i := range index {
+			index[i] = i
+		}
+		sort.SliceStable(index, func(i, j int) bool {
+			return arsenal.Clusters[index[i]].Count > arsenal.Clusters[index[j]].Count
+		})
+		sorted := make([]Cluster, len(index))
+		rank := make([]int, len(index))
+		for i, j := range index {
+			sorted[i] = arsenal.Clusters[j]
+			rank[j] = i
+		}
+		arsenal.Clusters = sorted
+
+		for i, p := range ps {
+			cl := rank[assignment[i]]
+			r.index[p.p] = len(r.Pitches)
+			r.Pitches = append(r.Pitches, Classification{
+				AtBat:    p.ab,
+				Pitch:    p.p,
+				Pitcher:  id,
+				Cluster:  cl,
+				Type:     sorted[cl].Type,
+				FeedType: p.p.PitchType,
+			})
+		}
+		r.Arsenals = append(r.Arsenals, arsenal)
+	}
+
+	return r
+}
+
+// cluster returns the centers of the clusters of a pitcher's pitches and the
+// index of the cluster of each pitch. It uses the most clusters, up to
+// MaxClusters, for which k-means finds clusters of at least MinPitches
+// pitches that are all separated.
+func (c *Classifier) cluster(ps []pitch) ([][6]float64, []int) {
+	for k := c.MaxClusters; k > 1; k-- {
+		if len(ps) < k*c.MinPitches {
+			continue
+		}
+		centers, assignment := kmeans(ps, k)
+		if c.separated(ps, centers, assignment) {
+			return centers, assignment
+		}
+	}
+	return kmeans(ps, 1)
+}
+
+// separated returns whether every cluster has at least MinPitches pitches and
+// is separated from every other: the distance between their centers is at
+// least Separation times the standard deviation of their pitches along the
+// line between the centers, or times one unit of distance if greater.
+func (c *Classifier) separated(ps []pitch, centers [][6]float64, assignment []int) bool {
+	counts := make([]int, len(centers))
+	for _, a := range assignment {
+		counts[a]++
+	}
+	for _, n := range counts {
+		if n < c.MinPitches {
+			return false
+		}
+	}
+
+	for i := range centers {
+		for j := i + 1; j < len(centers); j++ {
+			d := math.Sqrt(distance(centers[i], centers[j]))
+			var u [6]float64
+			for k := range u {
+				u[k] = (centers[j][k] - centers[i][k]) / d
+			}
+
+			// The variance of each cluster's pitches along u.
+			variance := 0.0
+			for n, p := range ps {
+				a := assignment[n]
+				if a != i && a != j {
+					continue
+				}
+				x := 0.0
+				for k := range u {
+					x += (p.vector[k] - centers[a][k]) * u[k]
+				}
+				variance += x * x
+			}
+			variance /= float64(counts[i] + counts[j])
+
+			// Clusters of a few pitches may have next to no spread; no kind
+			// of pitch varies less than the scales of its features.
+			if d < c.Separation*math.Sqrt(math.Max(variance, 1)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// kmeans clusters pitches into k clusters. The first center is the fastest
+// pitch and each following one the pitch farthest from the centers so far,
+// so the result is deterministic.
+func kmeans(ps []pitch, k int) ([][6]float64, []int) {
+	centers := make([][6]float64, 0, k)
+	fastest := 0
+	for i, p := range ps {
+		if p.vector[0] > ps[fastest].vector[0] {
+			fastest = i
+		}
+	}
+	centers = append(centers, ps[fastest].vector)
+	for len(centers) < k {
+		farthest, best := 0, -1.0
+		for i, p := range ps {
+			if d := nearest(centers, p.vector); d > best {
+				farthest, best = i, d
+			}
+		}
+		centers = append(centers, ps[farthest].vector)
+	}
+
+	assignment := make([]int, len(ps))
+	for iter := 0; iter < 100; iter++ {
+		changed := iter == 0
+		for i, p := range ps {
+			if a := closest(centers, p.vector); a != assignment[i] {
+				assignment[i], changed = a, true
+			}
+		}
+		if !changed {
+			break
+		}
+
+		sums := make([][6]float64, k)
+		counts := make([]int, k)
+		for i, p := range ps {
+			a := assignment[i]
+			counts[a]++
+			for j := range p.vector {
+				sums[a][j] += p.vector[j]
+			}
+		}
+		for a := range centers {
+			if counts[a] == 0 {
+				continue
+			}
+			for j := range sums[a] {
+				centers[a][j] = sums[a][j] / float64(counts[a])
+			}
+		}
+	}
+
+	return centers, assignment
+}
+
+// closest returns the index of the center closest to v.
+func closest(centers [][6]float64, v [6]float64) int {
+	best := 0
+	for i := range centers {
+		if distance(centers[i], v) < distance(centers[best], v) {
+			best = i
+		}
+	}
+	return best
+}
+
+// nearest returns the squared distance from v to the closest center.
+func nearest(centers [][6]float64, v [6]float64) float64 {
+	return distance(centers[closest(centers, v)], v)
+}
+
+// name names each cluster after the PitchType most often given with at
+// least MinConfidence to its pitches, or, if none was, after the prototype
+// it resembles most.
+func (c *Classifier) name(clusters []Cluster, ps []pitch, assignment []int) {
+	votes := make([]map[string]int, len(clusters))
+	for i := range votes {
+		votes[i] = make(map[string]int)
+	}
+	for i, p := range ps {
+		if p.p.PitchType != "" && p.p.TypeConfidence >= c.MinConfidence {
+			votes[assignment[i]][p.p.PitchType]++
+		}
+	}
+
+	fastest := 0.0
+	for _, cl := range clusters {
+		fastest = math.Max(fastest, cl.Center.Speed)
+	}
+	for i := range clusters {
+		best, n := "", 0
+		for t, v := range votes[i] {
+			if v > n || v == n && t < best {
+				best, n = t, v
+			}
+		}
+		if best == "" {
+			best = prototypeOf(clusters[i].Center, fastest)
+		}
+		clusters[i].Type = best
+	}
+}
+
+// prototype represents the typical movement of a kind of pitch.
+type prototype struct {
+	Type string
+
+	// SpeedLoss is the difference in speed from the pitcher's fastest
+	// pitch, in miles per hour, and ArmSide and Rise the movement, in
+	// inches.
+	SpeedLoss float64
+	ArmSide   float64
+	Rise      float64
+}
+
+// prototypes lists the typical movement of the common kinds of pitch.
+var prototypes = []prototype{
+	{"FF", 0, 6, 9},
+	{"SI", 1, 9, 4},
+	{"FC", 5, -2, 5},
+	{"SL", 8, -3, 1},
+	{"CU", 14, -6, -6},
+	{"CH", 8, 8, 3},
+	{"FS", 8, 5, 0},
+}
+
+// prototypeOf returns the type of the prototype closest to a cluster's
+// center, given the speed of the pitcher's fastest cluster.
+func prototypeOf(center Features, fastest float64) string {
+	best, d := "", math.Inf(1)
+	for _, p := range prototypes {
+		dv := (fastest - center.Speed - p.SpeedLoss) / scales[0]
+		dx := (center.ArmSide - p.ArmSide) / scales[2]
+		dz := (center.Rise - p.Rise) / scales[3]
+		if e := dv*dv + dx*dx + dz*dz; e < d {
+			best, d = p.Type, e
+		}
+	}
+	return best
+}
@@ -0,0 +1,143 @@
+package pitchclass
+
+import (
+	"testing"
+
+	"github.com/ericdreeves/mlbgameday"
+	"github.com/ericdreeves/mlbgameday/internal/testutil"
+)
+
+func TestClassify(t *testing.T) {
+	r := NewClassifier().Classify(testutil.AtBats(t))
+
+	if len(r.Pitches) != 327 || len(r.Arsenals) != 11 {
+		t.Fatalf("Classify returned %v pitches by %v pitchers, want 327 by 11",
+			len(r.Pitches), len(r.Arsenals))
+	}
+
+	var arsenal *Arsenal
+	for i := range r.Arsenals {
+		if r.Arsenals[i].Pitcher == 453178 {
+			arsenal = &r.Arsenals[i]
+		}
+	}
+	if arsenal == nil {
+		t.Fatal("Classify returned no arsenal for pitcher 453178")
+	}
+	var testCases = []struct {
+		Type  string
+		Count int
+	}{
+		{"FF", 58},
+		{"KC", 15},
+		{"SL", 14},
+		{"CH", 7},
+	}
+	if len(arsenal.Clusters) != len(testCases) {
+		t.Fatalf("Classify returned arsenal %+v, want %v clusters", arsenal, len(testCases))
+	}
+	for i, tc := range testCases {
+		if c := arsenal.Clusters[i]; c.Type != tc.Type || c.Count != tc.Count {
+			t.Errorf("Classify returned cluster %+v, want %v %v", c, tc.Count, tc.Type)
+		}
+	}
+	if arsenal.PThrows != "R" || arsenal.Clusters[0].Center.ArmSide <= 0 {
+		t.Errorf("Classify returned fastball center %+v, want positive arm side movement",
+			arsenal.Clusters[0].Center)
+	}
+
+	// Pitches are grouped by pitcher and typed after their cluster.
+	seen := make(map[int]bool)
+	for i, c := range r.Pitches {
+		if i > 0 && c.Pitcher != r.Pitches[i-1].Pitcher {
+			if seen[c.Pitcher] {
+				t.Fatalf("Classify returned pitches of %v apart", c.Pitcher)
+			}
+		}
+		seen[c.Pitcher] = true
+	}
+
+	// A 91 mph pitch with the rise of a fastball, classified as a slider with
+	// low confidence.
+	var found bool
+	for _, c := range r.Disagreements() {
+		if c.Pitch.EventNum == 647 {
+			found = c.FeedType == "SL" && c.Type == "FF" && c.Pitcher == 572044
+		}
+		if !c.Disagrees() {
+			t.Errorf("Report.Disagreements returned %+v", c)
+		}
+	}
+	if !found {
+		t.Errorf("Report.Disagreements did not return pitch 647 as a fastball")
+	}
+}
+
+func TestReportTypeOf(t *testing.T) {
+	r := NewClassifier().Classify(testutil.AtBats(t))
+
+	var disagreement Classification
+	for _, c := range r.Disagreements() {
+		if c.Pitch.EventNum == 647 {
+			disagreement = c
+		}
+	}
+	if disagreement.Pitch == nil {
+		t.Fatal("Classify did not return pitch 647")
+	}
+	if got := r.TypeOf(disagreement.Pitch); got != "FF" {
+		t.Errorf("Report.TypeOf returned %v for pitch 647, want FF", got)
+	}
+
+	// Reports not built by Classify, and nil reports, type pitches too.
+	copied := &Report{Pitches: r.Pitches}
+	if got := copied.TypeOf(disagreement.Pitch); got != "FF" {
+		t.Errorf("Report.TypeOf returned %v for pitch 647 of a copied report, want FF", got)
+	}
+	var none *Report
+	if got := none.TypeOf(disagreement.Pitch); got != "SL" {
+		t.Errorf("Report.TypeOf returned %v for pitch 647 of a nil report, want SL", got)
+	}
+	if got := r.TypeOf(&mlbgameday.Pitch{Des: "Ball"}); got != Unknown {
+		t.Errorf("Report.TypeOf returned %v for an untyped pitch, want %v", got, Unknown)
+	}
+}
+
+func TestFeaturesOf(t *testing.T) {
+	abs := testutil.AtBats(t)
+	ab := &abs.Innings[0].Top[0]
+	p := &ab.Pitches[0]
+
+	right, ok := FeaturesOf(ab, p)
+	if !ok {
+		t.Fatal("FeaturesOf returned false for a tracked pitch")
+	}
+	left, _ := FeaturesOf(&mlbgameday.AtBat{PThrows: "L"}, p)
+	if left.ArmSide != -right.ArmSide || left.ReleaseSide != -right.ReleaseSide ||
+		left.Rise != right.Rise || left.Speed != float64(p.StartSpeed) {
+		t.Errorf("FeaturesOf returned %+v for a left-hander and %+v for a right-hander, want mirrored",
+			left, right)
+	}
+
+	if _, ok := FeaturesOf(ab, &mlbgameday.Pitch{Des: "Ball"}); ok {
+		t.Errorf("FeaturesOf returned true for an untracked pitch")
+	}
+}
+
+func TestPrototypeOf(t *testing.T) {
+	var testCases = []struct {
+		Center Features
+		Want   string
+	}{
+		{Features{Speed: 95, ArmSide: 6, Rise: 10}, "FF"},
+		{Features{Speed: 94, ArmSide: 10, Rise: 3}, "SI"},
+		{Features{Speed: 87, ArmSide: -3, Rise: 1}, "SL"},
+		{Features{Speed: 80, ArmSide: -7, Rise: -5}, "CU"},
+		{Features{Speed: 87, ArmSide: 8, Rise: 4}, "CH"},
+	}
+	for _, tc := range testCases {
+		if got := prototypeOf(tc.Center, 95); got != tc.Want {
+			t.Errorf("prototypeOf(%+v) returned %v, want %v", tc.Center, got, tc.Want)
+		}
+	}
+}
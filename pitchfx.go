@@ -0,0 +1,223 @@
+package mlbgameday
+
+import "math"
+
+// Distances along the y axis of the PitchF/X coordinate system, in feet from
+// the back tip of home plate toward the pitcher's mound.
+const (
+	// PlateY is the front of home plate, where PX and PZ are measured.
+	PlateY = 17.0 / 12
+
+	// PfxY is where the movement measured by PfxX and PfxZ starts.
+	PfxY = 40.0
+
+	// ReleaseY is the release distance assumed by Pitch.Trajectory. PitchF/X
+	// does not track the ball to the pitcher's hand.
+	ReleaseY = 55.0
+
+	// RubberY is the front of the pitching rubber.
+	RubberY = 60.5
+)
+
+// Physical constants of a baseball in flight.
+const (
+	gravity    = 32.174                     // ft/s²
+	ballRadius = 9.125 / (2 * math.Pi) / 12 // ft
+	ballMass   = 5.125 / 16                 // lb
+	airDensity = 0.0740                     // lb/ft³ at sea level and 70°F
+)
+
+// Vector represents a position, velocity or acceleration in the PitchF/X
+// coordinate system, in feet, feet per second or feet per second squared.
+// X points to the catcher's right, Y toward the pitcher and Z up.
+type Vector struct {
+	X float64
+	Y float64
+	Z float64
+}
+
+// Norm returns the length of the vector.
+func (v Vector) Norm() float64 {
+	return math.Sqrt(v.X*v.X + v.Y*v.Y + v.Z*v.Z)
+}
+
+// Trajectory represents the constant acceleration fit of a pitch's flight.
+// Time is measured in seconds from the moment the ball was at Y0.
+type Trajectory struct {
+	X0, Y0, Z0    float64
+	VX0, VY0, VZ0 float64
+	AX, AY, AZ    float64
+
+	// ReleaseY is the distance from home plate at which the ball was
+	// released. Set it from the pitcher's measured extension, if known, as
+	// RubberY - extension.
+	ReleaseY float64
+}
+
+// Trajectory returns the nine-parameter fit of the pitch's flight, released
+// at ReleaseY.
+func (p *Pitch) Trajectory() Trajectory {
+	return Trajectory{
+		float64(p.X0), float64(p.Y0), float64(p.Z0),
+		float64(p.VX0), float64(p.VY0), float64(p.VZ0),
+		float64(p.AX), float64(p.AY), float64(p.AZ),
+		ReleaseY,
+	}
+}
+
+// Position returns the position of the ball at time t.
+func (tr Trajectory) Position(t float64) Vector {
+	return Vector{
+		tr.X0 + tr.VX0*t + tr.AX*t*t/2,
+		tr.Y0 + tr.VY0*t + tr.AY*t*t/2,
+		tr.Z0 + tr.VZ0*t + tr.AZ*t*t/2,
+	}
+}
+
+// Velocity returns the velocity of the ball at time t.
+func (tr Trajectory) Velocity(t float64) Vector {
+	return Vector{tr.VX0 + tr.AX*t, tr.VY0 + tr.AY*t, tr.VZ0 + tr.AZ*t}
+}
+
+// Time returns the time at which the ball crossed the plane at distance y
+// from home plate, which is negative before Y0. It returns NaN if the ball
+// never reached the plane.
+func (tr Trajectory) Time(y float64) float64 {
+	if tr.AY == 0 {
+		return (y - tr.Y0) / tr.VY0
+	}
+	d := tr.VY0*tr.VY0 - 2*tr.AY*(tr.Y0-y)
+	if d < 0 {
+		return math.NaN()
+	}
+	// The ball travels toward home plate on the root with negative velocity.
+	return (-tr.VY0 - math.Sqrt(d)) / tr.AY
+}
+
+// PositionAt returns the position of the ball at distance y from home plate.
+func (tr Trajectory) PositionAt(y float64) Vector {
+	return tr.Position(tr.Time(y))
+}
+
+// VelocityAt returns the velocity of the ball at distance y from home plate.
+func (tr Trajectory) VelocityAt(y float64) Vector {
+	return tr.Velocity(tr.Time(y))
+}
+
+// SpeedAt returns the speed of the ball, in miles per hour, at distance y
+// from home plate. The speed at Y0 is StartSpeed and the speed at PlateY is
+// EndSpeed.
+func (tr Trajectory) SpeedAt(y float64) float64 {
+	return tr.VelocityAt(y).Norm() * 3600 / 5280
+}
+
+// Release returns the release point of the pitch.
+func (tr Trajectory) Release() Vector {
+	return tr.PositionAt(tr.ReleaseY)
+}
+
+// Extension returns the distance, in feet, in front of the rubber at which
+// the pitch was released.
+func (tr Trajectory) Extension() float64 {
+	return RubberY - tr.ReleaseY
+}
+
+// Plate returns the position of the ball at the front of home plate. Its X
+// and Z are PX and PZ.
+func (tr Trajectory) Plate() Vector {
+	return tr.PositionAt(PlateY)
+}
+
+// PlateTime returns the time, in seconds, from the release of the pitch to
+// its crossing the front of home plate.
+func (tr Trajectory) PlateTime() float64 {
+	return tr.Time(PlateY) - tr.Time(tr.ReleaseY)
+}
+
+// ApproachAngles returns the vertical and horizontal angles, in degrees, at
+// which the ball crossed the front of home plate. A pitch descending toward
+// the plate has a negative vertical angle and one moving toward the catcher's
+// right a positive horizontal angle.
+func (tr Trajectory) ApproachAngles() (vertical, horizontal float64) {
+	v := tr.VelocityAt(PlateY)
+	return degrees(math.Atan2(v.Z, -v.Y)), degrees(math.Atan2(v.X, -v.Y))
+}
+
+// Pfx returns the horizontal and vertical movement of the pitch, in inches,
+// from PfxY to the front of home plate relative to a pitch thrown without
+// spin, as reported by PfxX and PfxZ.
+func (tr Trajectory) Pfx() (x, z float64) {
+	return tr.breakBetween(PfxY, PlateY)
+}
+
+// InducedBreak returns the horizontal and vertical movement of the pitch, in
+// inches, from its release to the front of home plate relative to a pitch
+// thrown without spin.
+func (tr Trajectory) InducedBreak() (x, z float64) {
+	return tr.breakBetween(tr.ReleaseY, PlateY)
+}
+
+// TotalMovement returns the length, in inches, of the pitch's induced break.
+func (tr Trajectory) TotalMovement() float64 {
+	x, z := tr.InducedBreak()
+	return math.Hypot(x, z)
+}
+
+// breakBetween returns the movement of the pitch, in inches, due to
+// accelerations other than gravity and drag along y between two distances
+// from home plate.
+func (tr Trajectory) breakBetween(from, to float64) (x, z float64) {
+	t := tr.Time(to) - tr.Time(from)
+	return 6 * tr.AX * t * t, 6 * (tr.AZ + gravity) * t * t
+}
+
+// MagnusAcceleration returns the acceleration of the pitch due to its spin:
+// the component of its acceleration, less gravity, perpendicular to its
+// average velocity between release and home plate. The remaining component
+// is drag.
+func (tr Trajectory) MagnusAcceleration() Vector {
+	a := Vector{tr.AX, tr.AY, tr.AZ + gravity}
+	v := tr.averageVelocity()
+	n := v.Norm()
+	d := (a.X*v.X + a.Y*v.Y + a.Z*v.Z) / (n * n)
+	return Vector{a.X - d*v.X, a.Y - d*v.Y, a.Z - d*v.Z}
+}
+
+// TransverseSpin estimates the rate, in revolutions per minute, of the spin
+// of the pitch that produces Magnus movement, from its Magnus acceleration
+// using the lift model of Nathan (2008). It returns NaN if the acceleration
+// exceeds what any spin can produce.
+func (tr Trajectory) TransverseSpin() float64 {
+	v := tr.averageVelocity().Norm()
+	k := airDensity * math.Pi * ballRadius * ballRadius / (2 * ballMass)
+	cl := tr.MagnusAcceleration().Norm() / (k * v * v)
+
+	// CL = S / (0.4 + 2.32 S), where S is the spin parameter rω/v.
+	if cl >= 1/2.32 {
+		return math.NaN()
+	}
+	s := 0.4 * cl / (1 - 2.32*cl)
+	return s * v / ballRadius * 60 / (2 * math.Pi)
+}
+
+// averageVelocity returns the velocity of the ball halfway in time between
+// release and home plate.
+func (tr Trajectory) averageVelocity() Vector {
+	return tr.Velocity((tr.Time(tr.ReleaseY) + tr.Time(PlateY)) / 2)
+}
+
+// SpinEfficiency estimates the fraction of the pitch's SpinRate that produced
+// Magnus movement, from its Trajectory. The rest is gyro spin, which does not
+// move the ball. It returns 0 if SpinRate is not reported. Estimates above 1
+// point to a SpinRate lower than the movement of the pitch implies.
+func (p *Pitch) SpinEfficiency() float64 {
+	if p.SpinRate == 0 {
+		return 0
+	}
+	return p.Trajectory().TransverseSpin() / float64(p.SpinRate)
+}
+
+// degrees converts an angle from radians to degrees.
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
@@ -0,0 +1,121 @@
+package mlbgameday
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTrajectory(t *testing.T) {
+	abs := new(AtBats)
+	readMock(t, "./mock/inning_all.xml", abs)
+
+	var testCases = []struct {
+		Name      string
+		Computed  func(tr Trajectory) float64
+		Official  func(p *Pitch) float32
+		Tolerance float64
+	}{
+		{"PX", func(tr Trajectory) float64 { return tr.Plate().X },
+			func(p *Pitch) float32 { return p.PX }, 0.005},
+		{"PZ", func(tr Trajectory) float64 { return tr.Plate().Z },
+			func(p *Pitch) float32 { return p.PZ }, 0.005},
+		{"PfxX", func(tr Trajectory) float64 { x, _ := tr.Pfx(); return x },
+			func(p *Pitch) float32 { return p.PfxX }, 0.05},
+		{"PfxZ", func(tr Trajectory) float64 { _, z := tr.Pfx(); return z },
+			func(p *Pitch) float32 { return p.PfxZ }, 0.1},
+		{"StartSpeed", func(tr Trajectory) float64 { return tr.SpeedAt(tr.Y0) },
+			func(p *Pitch) float32 { return p.StartSpeed }, 0.1},
+		{"EndSpeed", func(tr Trajectory) float64 { return tr.SpeedAt(PlateY) },
+			func(p *Pitch) float32 { return p.EndSpeed }, 0.1},
+	}
+
+	n := 0
+	for _, inning := range abs.Innings {
+		for _, ab := range append(inning.Top, inning.Bottom...) {
+			for i := range ab.Pitches {
+				p := &ab.Pitches[i]
+				if p.VY0 == 0 {
+					continue
+				}
+				n++
+
+				tr := p.Trajectory()
+				for _, tc := range testCases {
+					got, want := tc.Computed(tr), float64(tc.Official(p))
+					if math.Abs(got-want) > tc.Tolerance {
+						t.Errorf("Pitch %v computed %v %v, want %v",
+							p.EventNum, tc.Name, got, want)
+					}
+				}
+			}
+		}
+	}
+	if n != 327 {
+		t.Errorf("Trajectory checked %v pitches, want 327", n)
+	}
+}
+
+func TestTrajectoryFlight(t *testing.T) {
+	abs := new(AtBats)
+	readMock(t, "./mock/inning_all.xml", abs)
+
+	// A 92.5 mph four-seam fastball.
+	p := &abs.Innings[0].Top[0].Pitches[0]
+	tr := p.Trajectory()
+
+	if r := tr.Release(); math.Abs(r.Y-ReleaseY) > 1e-9 || r.Z < 5 || r.Z > 7 {
+		t.Errorf("Trajectory.Release returned %+v, want a release 5 to 7 feet high at %v",
+			r, ReleaseY)
+	}
+	if e := tr.Extension(); e != RubberY-ReleaseY {
+		t.Errorf("Trajectory.Extension returned %v, want %v", e, RubberY-ReleaseY)
+	}
+	if pt := tr.PlateTime(); pt < 0.38 || pt > 0.45 {
+		t.Errorf("Trajectory.PlateTime returned %v, want 0.38 to 0.45", pt)
+	}
+
+	vaa, haa := tr.ApproachAngles()
+	if vaa > -4 || vaa < -7 || math.Abs(haa) > 3 {
+		t.Errorf("Trajectory.ApproachAngles returned %v, %v, want -4 to -7 and within 3",
+			vaa, haa)
+	}
+
+	x, z := tr.InducedBreak()
+	px, pz := tr.Pfx()
+	if z <= pz || math.Abs(x) <= math.Abs(px) {
+		t.Errorf("Trajectory.InducedBreak returned %v, %v, want more than Pfx %v, %v",
+			x, z, px, pz)
+	}
+	if m := tr.TotalMovement(); math.Abs(m-math.Hypot(x, z)) > 1e-9 {
+		t.Errorf("Trajectory.TotalMovement returned %v, want %v", m, math.Hypot(x, z))
+	}
+
+	if v := tr.VelocityAt(tr.Y0); v != (Vector{tr.VX0, tr.VY0, tr.VZ0}) {
+		t.Errorf("Trajectory.VelocityAt(Y0) returned %+v, want the initial velocity", v)
+	}
+
+	// The Magnus acceleration is perpendicular to the direction of flight.
+	a, v := tr.MagnusAcceleration(), tr.averageVelocity()
+	if dot := a.X*v.X + a.Y*v.Y + a.Z*v.Z; math.Abs(dot) > 1e-6 {
+		t.Errorf("Trajectory.MagnusAcceleration returned %+v, not perpendicular to %+v",
+			a, v)
+	}
+	if e := p.SpinEfficiency(); e < 0.5 || e > 1 {
+		t.Errorf("Pitch.SpinEfficiency returned %v, want 0.5 to 1", e)
+	}
+	if e := (&Pitch{}).SpinEfficiency(); e != 0 {
+		t.Errorf("Pitch.SpinEfficiency returned %v without a spin rate, want 0", e)
+	}
+}
+
+func TestTrajectoryTime(t *testing.T) {
+	tr := Trajectory{Y0: 50, VY0: -100}
+	if tm := tr.Time(0); tm != 0.5 {
+		t.Errorf("Trajectory.Time returned %v, want 0.5", tm)
+	}
+
+	tr.AY = 1000
+	if tm := tr.Time(0); !math.IsNaN(tm) {
+		t.Errorf("Trajectory.Time returned %v for a ball that stops short, want NaN", tm)
+	}
+}
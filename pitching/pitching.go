@@ -0,0 +1,389 @@
+// Package pitching reports how a pitcher pitched in a game.
+//
+// A Report breaks a pitcher's pitches down by type: how often he threw each
+// type by count and batter handedness, its velocity, spin, movement and
+// release point, and the swings and takes it drew. It also follows the
+// velocity of his fastball through the game and splits the plate appearances
+// by the times he had faced the batter's lineup slot.
+package pitching
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ericdreeves/mlbgameday"
+	"github.com/ericdreeves/mlbgameday/pitchclass"
+)
+
+// PitchCountBucket is the number of pitches in each part of
+// Report.ByPitchCount.
+const PitchCountBucket = 15
+
+// fastballs lists the types of fastball, from which a pitcher's fastball is
+// chosen for Report.ByInning and Report.ByPitchCount.
+var fastballs = []string{"FF", "FA", "FT", "SI"}
+
+// Mix counts the pitches thrown of each type.
+type Mix map[string]int
+
+// Total returns the number of pitches.
+func (m Mix) Total() int {
+	n := 0
+	for _, c := range m {
+		n += c
+	}
+	return n
+}
+
+// Share returns the fraction of pitches of a type.
+func (m Mix) Share(t string) float64 {
+	if n := m.Total(); n > 0 {
+		return float64(m[t]) / float64(n)
+	}
+	return 0
+}
+
+// TypeStats represents the pitches of a type.
+type TypeStats struct {
+	Type string
+
+	// Tracked is the number of pitches with PitchF/X data, from which the
+	// following measurements are taken. Speed and Spin are averages, in
+	// miles per hour and revolutions per minute.
+	Tracked  int
+	Speed    float64
+	MaxSpeed float64
+	Spin     float64
+	MaxSpin  float64
+
+	// HorizontalBreak and VerticalBreak are the average PfxX and PfxZ, in
+	// inches.
+	HorizontalBreak float64
+	VerticalBreak   float64
+
+	// Release is the average release point, and ReleaseSpread the root mean
+	// square distance, in feet, of the release points from it.
+	Release       mlbgameday.Vector
+	ReleaseSpread float64
+
+	mlbgameday.Discipline
+}
+
+// Velocity represents the speed of the pitcher's fastballs over part of a
+// game.
+type Velocity struct {
+	// From is the inning, or the pitch count of the first pitch, of the part.
+	From int
+
+	// Pitches is the number of tracked fastballs, and Speed their average
+	// speed, in miles per hour.
+	Pitches  int
+	Speed    float64
+	MaxSpeed float64
+}
+
+// Decline returns the difference in average speed between the first and last
+// parts of a game, positive if the pitcher lost velocity.
+func Decline(vs []Velocity) float64 {
+	if len(vs) == 0 {
+		return 0
+	}
+	return vs[0].Speed - vs[len(vs)-1].Speed
+}
+
+// Report represents how a pitcher pitched in a game.
+type Report struct {
+	Pitcher int
+	PThrows string
+
+	// Totals holds every plate appearance and pitch.
+	Totals mlbgameday.Split
+
+	// Types holds the pitches of each type, most thrown first. Release and
+	// ReleaseSpread are measured across all of them.
+	Types         []TypeStats
+	Release       mlbgameday.Vector
+	ReleaseSpread float64
+
+	// MixByCount holds the pitches thrown on each count, indexed by balls
+	// and strikes, and MixByStand those thrown to batters on each side of
+	// the plate, "L" or "R".
+	MixByCount [4][3]Mix
+	MixByStand map[string]Mix
+
+	// FirstPitches is the number of plate appearances whose first pitch the
+	// pitcher threw, and FirstPitchStrikes those whose first pitch was a
+	// strike or put in play. At-bats that were not plate appearances, such
+	// as one that ended the inning on a runner caught stealing, are not
+	// counted.
+	FirstPitches      int
+	FirstPitchStrikes int
+
+	// Fastball is the type of the pitcher's most thrown fastball or, if he
+	// threw none, of his fastest pitch. ByInning and ByPitchCount hold its
+	// velocity in each inning and each PitchCountBucket pitches he threw.
+	Fastball     string
+	ByInning     []Velocity
+	ByPitchCount []Velocity
+
+	// TimesThrough holds the plate appearances against batters in lineup
+	// slots the pitcher faced for the first, second and later times.
+	TimesThrough []mlbgameday.Split
+}
+
+// FirstPitchStrikeRate returns the fraction of plate appearances whose first
+// pitch was a strike.
+func (r *Report) FirstPitchStrikeRate() float64 {
+	if r.FirstPitches == 0 {
+		return 0
+	}
+	return float64(r.FirstPitchStrikes) / float64(r.FirstPitches)
+}
+
+// Evaluate returns the report of a pitcher in a game. Pitches are typed by
+// classes; see pitchclass.Report.TypeOf.
+func Evaluate(game mlbgameday.GameService, pitcher int, classes *pitchclass.Report) (*Report, error) {
+	abs, err := game.AtBats()
+	if err != nil {
+		return nil, err
+	}
+	return Build(abs, pitcher, classes), nil
+}
+
+// Build returns the report of a pitcher in the at-bats of a game. The report
+// of a pitcher who did not pitch is empty. See Evaluate.
+func Build(abs *mlbgameday.AtBats, pitcher int, classes *pitchclass.Report) *Report {
+	b := &builder{
+		r:     &Report{Pitcher: pitcher, MixByStand: make(map[string]Mix)},
+		types: make(map[string]*tracking),
+		faced: make(map[int]int),
+	}
+	for i := range b.r.MixByCount {
+		for j := range b.r.MixByCount[i] {
+			b.r.MixByCount[i][j] = make(Mix)
+		}
+	}
+
+	mlbgameday.WalkGameStates(abs, func(step mlbgameday.GameStep) error {
+		switch step.Kind {
+		case mlbgameday.PitchStep:
+			if step.Before.Defense().Pitcher == pitcher {
+				b.pitch(step, classes.TypeOf(step.Pitch))
+			}
+		case mlbgameday.PlateAppearanceStep:
+			if step.AtBat.Pitcher == pitcher {
+				b.plateAppearance(step)
+			} else {
+				// The pitcher was relieved during the plate appearance.
+				b.r.Totals.Discipline.Merge(b.discipline)
+				b.discipline, b.first = mlbgameday.Discipline{}, nil
+			}
+		}
+		return nil
+	})
+
+	return b.build()
+}
+
+// tracking accumulates the measurements of the tracked pitches of a type.
+type tracking struct {
+	stats TypeStats
+
+	// Sums of the measurements and, for the release point, their squares.
+	speed, spin, pfxX, pfxZ float64
+	release, releaseSq      mlbgameday.Vector
+}
+
+// add adds a pitch to the tracked pitches.
+func (t *tracking) add(p *mlbgameday.Pitch) {
+	if p.StartSpeed == 0 || p.VY0 == 0 {
+		return
+	}
+	release := p.Trajectory().Release()
+
+	s := &t.stats
+	s.Tracked++
+	s.MaxSpeed = math.Max(s.MaxSpeed, float64(p.StartSpeed))
+	s.MaxSpin = math.Max(s.MaxSpin, float64(p.SpinRate))
+	t.speed += float64(p.StartSpeed)
+	t.spin += float64(p.SpinRate)
+	t.pfxX += float64(p.PfxX)
+	t.pfxZ += float64(p.PfxZ)
+	t.release.X += release.X
+	t.release.Y += release.Y
+	t.release.Z += release.Z
+	t.releaseSq.X += release.X * release.X
+	t.releaseSq.Z += release.Z * release.Z
+}
+
+// averages returns the stats with the averages of the tracked pitches.
+func (t *tracking) averages() TypeStats {
+	s := t.stats
+	n := float64(s.Tracked)
+	if n == 0 {
+		return s
+	}
+	s.Speed = t.speed / n
+	s.Spin = t.spin / n
+	s.HorizontalBreak = t.pfxX / n
+	s.VerticalBreak = t.pfxZ / n
+	s.Release = mlbgameday.Vector{X: t.release.X / n, Y: t.release.Y / n, Z: t.release.Z / n}
+	variance := t.releaseSq.X/n - s.Release.X*s.Release.X +
+		t.releaseSq.Z/n - s.Release.Z*s.Release.Z
+	s.ReleaseSpread = math.Sqrt(math.Max(variance, 0))
+	return s
+}
+
+// typedPitch represents a pitch thrown by the pitcher, with the number of
+// pitches he had thrown up to it.
+type typedPitch struct {
+	p      *mlbgameday.Pitch
+	typ    string
+	inning int
+	count  int
+}
+
+// builder builds a Report.
+type builder struct {
+	r       *Report
+	types   map[string]*tracking
+	all     tracking
+	pitches []typedPitch
+
+	// faced counts the plate appearances against each lineup slot, and
+	// discipline the pitches of the current one. first is the first pitch
+	// of the current one if the pitcher threw it.
+	faced      map[int]int
+	discipline mlbgameday.Discipline
+	first      *mlbgameday.Pitch
+}
+
+// pitch accumulates a pitch of a type.
+func (b *builder) pitch(step mlbgameday.GameStep, typ string) {
+	r, p, ab := b.r, step.Pitch, step.AtBat
+	if r.PThrows == "" {
+		r.PThrows = ab.PThrows
+	}
+	b.pitches = append(b.pitches, typedPitch{p, typ, step.Before.Inning, len(b.pitches) + 1})
+
+	t, ok := b.types[typ]
+	if !ok {
+		t = &tracking{stats: TypeStats{Type: typ}}
+		b.types[typ] = t
+	}
+	t.add(p)
+	t.stats.Add(p)
+	b.all.add(p)
+	b.discipline.Add(p)
+
+	balls, strikes := step.Before.Balls, step.Before.Strikes
+	if balls > 3 {
+		balls = 3
+	}
+	if strikes > 2 {
+		strikes = 2
+	}
+	r.MixByCount[balls][strikes][typ]++
+	mix, ok := r.MixByStand[ab.Stand]
+	if !ok {
+		mix = make(Mix)
+		r.MixByStand[ab.Stand] = mix
+	}
+	mix[typ]++
+
+	if p == &ab.Pitches[0] {
+		b.first = p
+	}
+}
+
+// plateAppearance accumulates a plate appearance and its pitches.
+func (b *builder) plateAppearance(step mlbgameday.GameStep) {
+	r, ab := b.r, step.AtBat
+	d, first := b.discipline, b.first
+	b.discipline, b.first = mlbgameday.Discipline{}, nil
+	if !ab.IsPlateAppearance() {
+		r.Totals.Discipline.Merge(d)
+		return
+	}
+
+	if first != nil {
+		r.FirstPitches++
+		if first.Type == "S" || first.Type == "X" {
+			r.FirstPitchStrikes++
+		}
+	}
+
+	slot := step.Before.Offense().Slot
+	times := b.faced[slot]
+	b.faced[slot]++
+	for len(r.TimesThrough) <= times {
+		r.TimesThrough = append(r.TimesThrough, mlbgameday.Split{})
+	}
+
+	for _, s := range []*mlbgameday.Split{&r.Totals, &r.TimesThrough[times]} {
+		s.Discipline.Merge(d)
+		s.AddPlateAppearance(ab)
+	}
+}
+
+// build returns the report with its types ordered and velocities computed.
+func (b *builder) build() *Report {
+	r := b.r
+	r.Totals.Discipline.Merge(b.discipline)
+
+	for _, t := range b.types {
+		r.Types = append(r.Types, t.averages())
+	}
+	sort.Slice(r.Types, func(i, j int) bool {
+		if r.Types[i].Pitches != r.Types[j].Pitches {
+			return r.Types[i].Pitches > r.Types[j].Pitches
+		}
+		return r.Types[i].Type < r.Types[j].Type
+	})
+	all := b.all.averages()
+	r.Release, r.ReleaseSpread = all.Release, all.ReleaseSpread
+
+	r.Fastball = fastballOf(r.Types)
+	for _, p := range b.pitches {
+		if p.typ != r.Fastball || p.p.StartSpeed == 0 {
+			continue
+		}
+		speed := float64(p.p.StartSpeed)
+		r.ByInning = addVelocity(r.ByInning, p.inning, speed)
+		from := (p.count-1)/PitchCountBucket*PitchCountBucket + 1
+		r.ByPitchCount = addVelocity(r.ByPitchCount, from, speed)
+	}
+	return r
+}
+
+// fastballOf returns the type of the most thrown fastball among types, or of
+// the fastest type if none is a fastball.
+func fastballOf(types []TypeStats) string {
+	for _, t := range types {
+		for _, f := range fastballs {
+			if t.Type == f {
+				return f
+			}
+		}
+	}
+	fastest, speed := "", 0.0
+	for _, t := range types {
+		if t.Speed > speed {
+			fastest, speed = t.Type, t.Speed
+		}
+	}
+	return fastest
+}
+
+// addVelocity adds the speed of a fastball to the part of a game starting at
+// from, which is the last of vs or follows it.
+func addVelocity(vs []Velocity, from int, speed float64) []Velocity {
+	if len(vs) == 0 || vs[len(vs)-1].From != from {
+		vs = append(vs, Velocity{From: from})
+	}
+	v := &vs[len(vs)-1]
+	v.Speed = (v.Speed*float64(v.Pitches) + speed) / float64(v.Pitches+1)
+	v.Pitches++
+	v.MaxSpeed = math.Max(v.MaxSpeed, speed)
+	return vs
+}
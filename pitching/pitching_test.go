@@ -0,0 +1,166 @@
+package pitching
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/ericdreeves/mlbgameday"
+	"github.com/ericdreeves/mlbgameday/internal/testutil"
+	"github.com/ericdreeves/mlbgameday/pitchclass"
+)
+
+func TestEvaluate(t *testing.T) {
+	r, err := Evaluate(testutil.NewGame(t), 453178, nil)
+	if err != nil {
+		t.Fatalf("Evaluate returned error: %v", err)
+	}
+
+	if r.PThrows != "R" {
+		t.Errorf("Evaluate returned PThrows %v, want R", r.PThrows)
+	}
+	totals := r.Totals
+	if totals.PlateAppearances != 27 || totals.Pitches != 94 || totals.Hits != 9 ||
+		totals.HomeRuns != 2 || totals.Walks != 2 || totals.Strikeouts != 6 {
+		t.Errorf("Evaluate returned totals %+v, want 94 pitches to 27 batters, 9 hits, 2 home runs, 2 walks and 6 strikeouts",
+			totals)
+	}
+	if r.FirstPitches != 27 || r.FirstPitchStrikes != 17 {
+		t.Errorf("Evaluate returned %v first-pitch strikes of %v, want 17 of 27",
+			r.FirstPitchStrikes, r.FirstPitches)
+	}
+
+	var testCases = []struct {
+		Type    string
+		Pitches int
+	}{
+		{"FF", 58},
+		{"KC", 15},
+		{"SL", 14},
+		{"CH", 7},
+	}
+	if len(r.Types) != len(testCases) {
+		t.Fatalf("Evaluate returned types %+v, want %v", r.Types, len(testCases))
+	}
+	for i, tc := range testCases {
+		if s := r.Types[i]; s.Type != tc.Type || s.Pitches != tc.Pitches || s.Tracked != tc.Pitches {
+			t.Errorf("Evaluate returned type %+v, want %v %v", s, tc.Pitches, tc.Type)
+		}
+	}
+	ff := r.Types[0]
+	if math.Abs(ff.Speed-92.37) > 0.01 || math.Abs(ff.MaxSpeed-94.1) > 0.01 {
+		t.Errorf("Evaluate returned fastball speed %v and max %v, want 92.37 and 94.1",
+			ff.Speed, ff.MaxSpeed)
+	}
+	if ff.HorizontalBreak > -4 || ff.VerticalBreak < 8 || ff.Spin < 1900 || ff.Spin > ff.MaxSpin {
+		t.Errorf("Evaluate returned fastball movement %+v, want arm side run and rise", ff)
+	}
+	if ff.ReleaseSpread <= 0 || ff.ReleaseSpread > 0.3 || r.ReleaseSpread < ff.ReleaseSpread/2 {
+		t.Errorf("Evaluate returned release spread %v for fastballs and %v for all pitches, want up to 0.3 feet",
+			ff.ReleaseSpread, r.ReleaseSpread)
+	}
+
+	mixed := 0
+	for _, row := range r.MixByCount {
+		for _, m := range row {
+			mixed += m.Total()
+		}
+	}
+	if mixed != 94 || r.MixByCount[0][0]["FF"] != 16 {
+		t.Errorf("Evaluate returned %v pitches by count, %v first-pitch fastballs, want 94 and 16",
+			mixed, r.MixByCount[0][0]["FF"])
+	}
+	if r.MixByStand["L"]["SL"] != 3 || r.MixByStand["R"]["SL"] != 11 {
+		t.Errorf("Evaluate returned sliders by stand %+v, want 3 to left-handers and 11 to right-handers",
+			r.MixByStand)
+	}
+
+	if len(r.TimesThrough) != 3 {
+		t.Fatalf("Evaluate returned %v times through the order, want 3", len(r.TimesThrough))
+	}
+	pitches := 0
+	for _, s := range r.TimesThrough {
+		if s.PlateAppearances != 9 {
+			t.Errorf("Evaluate returned %+v, want 9 plate appearances each time through", s)
+		}
+		pitches += s.Pitches
+	}
+	if pitches != 94 {
+		t.Errorf("Evaluate returned %v pitches through the order, want 94", pitches)
+	}
+
+	if r.Fastball != "FF" || len(r.ByInning) != 6 || r.ByInning[5].From != 6 {
+		t.Errorf("Evaluate returned fastball %v by inning %+v, want FF in innings 1 to 6",
+			r.Fastball, r.ByInning)
+	}
+	if len(r.ByPitchCount) != 7 || r.ByPitchCount[6].From != 91 {
+		t.Errorf("Evaluate returned fastball by pitch count %+v, want 7 parts up to pitch 91",
+			r.ByPitchCount)
+	}
+	if d := Decline(r.ByInning); math.Abs(d-(r.ByInning[0].Speed-r.ByInning[5].Speed)) > 1e-9 {
+		t.Errorf("Decline returned %v", d)
+	}
+
+	_, err = Evaluate(&testutil.Game{Err: errors.New("Not found")}, 453178, nil)
+	if err == nil || err.Error() != "Not found" {
+		t.Errorf("Evaluate returned error %v, want Not found", err)
+	}
+}
+
+func TestBuildClasses(t *testing.T) {
+	abs := testutil.AtBats(t)
+
+	// The feed calls some of the fastballs of 572990 sliders; clustering
+	// types them as fastballs.
+	r := Build(abs, 572990, pitchclass.NewClassifier().Classify(abs))
+	if len(r.Types) != 2 || r.Types[0].Type != "FF" || r.Types[0].Pitches != 13 ||
+		r.Types[1].Type != "FS" || r.Types[1].Pitches != 12 {
+		t.Errorf("Build returned types %+v, want 13 FF and 12 FS", r.Types)
+	}
+
+	if r := Build(abs, 1, nil); r.Totals.Pitches != 0 || len(r.Types) != 0 {
+		t.Errorf("Build returned %+v for a pitcher who did not pitch, want an empty report", r)
+	}
+}
+
+func TestBuildNotPlateAppearance(t *testing.T) {
+	// The first inning ends on a runner caught stealing during the first
+	// at-bat of batter 10, who leads off the second inning and grounds out.
+	abs := &mlbgameday.AtBats{Innings: []mlbgameday.AtBatInning{
+		{Number: 1, Top: []mlbgameday.AtBat{{
+			AtBatSummary: mlbgameday.AtBatSummary{
+				Number: 1, Batter: 10, Pitcher: 2, Outs: 3, Event: "Caught Stealing 2B",
+			},
+			Pitches: []mlbgameday.Pitch{{Des: "Ball", Type: "B"}, {Des: "Ball", Type: "B"}},
+		}}},
+		{Number: 2, Top: []mlbgameday.AtBat{{
+			AtBatSummary: mlbgameday.AtBatSummary{
+				Number: 2, Batter: 10, Pitcher: 2, Outs: 1, Event: "Groundout",
+			},
+			Pitches: []mlbgameday.Pitch{
+				{Des: "Called Strike", Type: "S"},
+				{Des: "In play, out(s)", Type: "X"},
+			},
+		}}},
+	}}
+
+	r := Build(abs, 2, nil)
+	if r.Totals.PlateAppearances != 1 || r.Totals.Pitches != 4 {
+		t.Errorf("Build returned totals %+v, want 4 pitches in 1 plate appearance", r.Totals)
+	}
+	if r.FirstPitches != 1 || r.FirstPitchStrikes != 1 {
+		t.Errorf("Build returned %v first-pitch strikes of %v, want 1 of 1",
+			r.FirstPitchStrikes, r.FirstPitches)
+	}
+}
+
+func TestMix(t *testing.T) {
+	m := Mix{"FF": 3, "SL": 1}
+	if m.Total() != 4 || m.Share("FF") != 0.75 || m.Share("CU") != 0 {
+		t.Errorf("Mix returned total %v and shares %v and %v, want 4, 0.75 and 0",
+			m.Total(), m.Share("FF"), m.Share("CU"))
+	}
+	if (Mix{}).Share("FF") != 0 || Decline(nil) != 0 {
+		t.Errorf("Mix.Share and Decline returned nonzero without pitches")
+	}
+}
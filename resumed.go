@@ -0,0 +1,224 @@
+package mlbgameday
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IsDoubleHeader returns whether the game is one of two games played by the
+// same teams on the same date.
+func (g *Game) IsDoubleHeader() bool {
+	return g.DoubleHeader == "Y" || g.DoubleHeader == "S"
+}
+
+// IsSuspended returns whether play of the game has been suspended, to be
+// resumed on a later date.
+func (g *Game) IsSuspended() bool {
+	return strings.HasPrefix(g.Status, "Suspended")
+}
+
+// IsResumed returns whether the game is the continuation of a game that was
+// suspended on an earlier date. A resumed game keeps the GID of the date on
+// which it began, while a game postponed to a later date is given a GID of
+// the date on which it is played, so only the former is played after the
+// date of its GID. Games without a Gameday GID, such as those of the MLB
+// Stats API, are resumed if played after their OriginalDate.
+func (g *Game) IsResumed() bool {
+	if len(g.TimeDate) < 10 {
+		return false
+	}
+	played, err := ParseGameDate(g.TimeDate[:10])
+	if err != nil {
+		return false
+	}
+
+	if d, ok := dateFromGID(g.GID); ok {
+		return played.After(d)
+	}
+	if original, err := ParseGameDate(g.OriginalDate); err == nil {
+		return played.After(original)
+	}
+
+	return false
+}
+
+// Resumption returns the date on which a suspended game will be resumed. It
+// returns false if the game has no resume date.
+func (g *Game) Resumption() (time.Time, bool) {
+	d, err := ParseGameDate(g.ResumeDate)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return d.Time(), true
+}
+
+// GameNumberFromGID returns the number of the game referenced by the provided
+// game ID: 2 for the second game of a doubleheader and 1 otherwise. It
+// returns false if the game ID is not valid.
+func GameNumberFromGID(gid string) (int, bool) {
+	toks := strings.Split(gid, "_")
+	if len(toks) != 6 {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(toks[5])
+	if err != nil {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// dateFromGID returns the date of the game referenced by the provided game
+// ID. It returns false if the game ID is not valid.
+func dateFromGID(gid string) (GameDate, bool) {
+	toks := strings.Split(gid, "_")
+	if len(toks) != 6 {
+		return GameDate{}, false
+	}
+
+	d, err := ParseGameDate(strings.Join(toks[:3], "/"))
+	if err != nil {
+		return GameDate{}, false
+	}
+
+	return d, true
+}
+
+// NewResumedGameService returns a new GameServiceOp for a suspended game that
+// was resumed on the provided date. Resources for the game are retrieved from
+// the folder of the date on which it was resumed, or from the folder of the
+// date on which it began until the former is published, and its at-bats are
+// merged from the folders of both dates. See NewGameService.
+func NewResumedGameService(client *Client, gid string, resumed time.Time) (GameService, error) {
+	sport, ok := SportFromGID(gid)
+	if !ok {
+		sport = MLB
+	}
+
+	return newResumedGameService(client, sport, gid, resumed)
+}
+
+// newResumedGameService returns a new GameServiceOp for a game of the
+// provided sport that was resumed on the provided date.
+func newResumedGameService(client *Client, sport Sport, gid string, resumed time.Time) (GameService, error) {
+	original, err := pathFromGID(gid, sport, "")
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("%sgid_%s/", DateOf(resumed).path(sport), gid)
+	if path == original {
+		return &GameServiceOp{client: client, gid: gid, path: path}, nil
+	}
+
+	return &GameServiceOp{
+		client: client,
+		gid:    gid,
+		path:   path,
+		parts:  []string{original, path},
+	}, nil
+}
+
+// getResource retrieves the resource at the provided path within the game's
+// folder and decodes it into v. Resources of a resumed game that are not
+// found in the folder of its resumption, which is not published until play
+// resumes, are retrieved from the folder of its first part.
+func (s *GameServiceOp) getResource(resource string, v interface{}) error {
+	err := s.client.getResource(s.path+resource, v)
+	if e, ok := err.(*HTTPError); ok && e.StatusCode == 404 && len(s.parts) > 0 {
+		return s.client.getResource(s.parts[0]+resource, v)
+	}
+
+	return err
+}
+
+// resumedAtBats returns the at-bats from every part of a resumed game. Parts
+// after the first that have not been published yet are skipped.
+func (s *GameServiceOp) resumedAtBats() (*AtBats, error) {
+	all := new(AtBats)
+	for i, part := range s.parts {
+		g := new(AtBats)
+		if err := s.client.getResource(part+"inning/inning_all", g); err != nil {
+			if e, ok := err.(*HTTPError); ok && e.StatusCode == 404 && i > 0 {
+				continue
+			}
+			return nil, err
+		}
+		mergeAtBats(all, g)
+	}
+
+	return all, nil
+}
+
+// mergeAtBats adds the at-bats in src to dst. Innings are matched by number
+// and at-bats by their number within the game, with at-bats in src replacing
+// those already in dst.
+func mergeAtBats(dst, src *AtBats) {
+	for _, inning := range src.Innings {
+		i := 0
+		for i < len(dst.Innings) && dst.Innings[i].Number != inning.Number {
+			i++
+		}
+		if i == len(dst.Innings) {
+			dst.Innings = append(dst.Innings, AtBatInning{Number: inning.Number})
+		}
+
+		dst.Innings[i].Top = mergeHalfInning(dst.Innings[i].Top, inning.Top)
+		dst.Innings[i].Bottom = mergeHalfInning(dst.Innings[i].Bottom, inning.Bottom)
+		dst.Innings[i].TopActions = mergeActions(dst.Innings[i].TopActions, inning.TopActions)
+		dst.Innings[i].BottomActions = mergeActions(dst.Innings[i].BottomActions, inning.BottomActions)
+	}
+
+	sort.SliceStable(dst.Innings, func(i, j int) bool {
+		return dst.Innings[i].Number < dst.Innings[j].Number
+	})
+}
+
+// mergeHalfInning returns the at-bats in dst and src ordered by number, with
+// at-bats in src replacing those in dst that have the same number.
+func mergeHalfInning(dst, src []AtBat) []AtBat {
+	for _, ab := range src {
+		i := 0
+		for i < len(dst) && dst[i].Number != ab.Number {
+			i++
+		}
+		if i == len(dst) {
+			dst = append(dst, ab)
+		} else {
+			dst[i] = ab
+		}
+	}
+
+	sort.SliceStable(dst, func(i, j int) bool {
+		return dst[i].Number < dst[j].Number
+	})
+
+	return dst
+}
+
+// mergeActions returns the actions in dst and src ordered by event number,
+// with actions in src replacing those in dst that have the same event number.
+func mergeActions(dst, src []Action) []Action {
+	for _, a := range src {
+		i := 0
+		for i < len(dst) && dst[i].EventNum != a.EventNum {
+			i++
+		}
+		if i == len(dst) {
+			dst = append(dst, a)
+		} else {
+			dst[i] = a
+		}
+	}
+
+	sort.SliceStable(dst, func(i, j int) bool {
+		return dst[i].EventNum < dst[j].EventNum
+	})
+
+	return dst
+}
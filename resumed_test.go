@@ -0,0 +1,316 @@
+package mlbgameday
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const resumedGID = "2016_09_04_kcamlb_minmlb_1"
+
+func setupResumed(t *testing.T, resumed bool) {
+	mux.HandleFunc("/components/game/mlb/year_2016/month_09/day_05/miniscoreboard.xml",
+		func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			serveFile(t, w, "./mock/resumed/miniscoreboard.xml")
+		})
+	mux.HandleFunc("/components/game/mlb/year_2016/month_09/day_04/gid_"+
+		resumedGID+"/inning/inning_all.xml",
+		func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			serveFile(t, w, "./mock/resumed/inning_all_suspended.xml")
+		})
+	if !resumed {
+		return
+	}
+	mux.HandleFunc("/components/game/mlb/year_2016/month_09/day_05/gid_"+
+		resumedGID+"/inning/inning_all.xml",
+		func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			serveFile(t, w, "./mock/resumed/inning_all_resumed.xml")
+		})
+}
+
+func resumedDate() time.Time {
+	l, _ := time.LoadLocation("America/New_York")
+	return time.Date(2016, 9, 5, 0, 0, 0, 0, l)
+}
+
+func TestGameDoubleHeader(t *testing.T) {
+	var testCases = []struct {
+		Game         Game
+		DoubleHeader bool
+		Suspended    bool
+		Resumed      bool
+	}{
+		{Game{DoubleHeader: "N", Status: "Final"}, false, false, false},
+		{Game{DoubleHeader: "Y", Status: "Final"}, true, false, false},
+		{Game{DoubleHeader: "S", Status: "Suspended"}, true, true, false},
+		{
+			Game{
+				GID:      "448916",
+				TimeDate: "2016/09/05 12:10", OriginalDate: "2016/09/04",
+				Status: "In Progress",
+			},
+			false, false, true,
+		},
+		{
+			Game{TimeDate: "2016/09/05 1:05", OriginalDate: "2016/09/05"},
+			false, false, false,
+		},
+		{
+			Game{
+				GID:      "2016_09_04_kcamlb_minmlb_1",
+				TimeDate: "2016/09/05 12:10", OriginalDate: "2016/09/04",
+				Status: "In Progress",
+			},
+			false, false, true,
+		},
+		{
+			// A game postponed from 2016/09/03 and made up on 2016/09/05.
+			Game{
+				GID:      "2016_09_05_kcamlb_minmlb_2",
+				TimeDate: "2016/09/05 7:10", OriginalDate: "2016/09/03",
+				DoubleHeader: "S", Status: "Preview",
+			},
+			true, false, false,
+		},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.Game.IsDoubleHeader(); got != tc.DoubleHeader {
+			t.Errorf("%+v.IsDoubleHeader() == %v, want %v", tc.Game, got, tc.DoubleHeader)
+		}
+		if got := tc.Game.IsSuspended(); got != tc.Suspended {
+			t.Errorf("%+v.IsSuspended() == %v, want %v", tc.Game, got, tc.Suspended)
+		}
+		if got := tc.Game.IsResumed(); got != tc.Resumed {
+			t.Errorf("%+v.IsResumed() == %v, want %v", tc.Game, got, tc.Resumed)
+		}
+	}
+}
+
+func TestGameResumption(t *testing.T) {
+	g := Game{Status: "Suspended", ResumeDate: "2016/09/05"}
+	got, ok := g.Resumption()
+	if !ok || !got.Equal(resumedDate()) {
+		t.Errorf("Game.Resumption() == %v, %v, want %v, true", got, ok, resumedDate())
+	}
+
+	g = Game{Status: "Final"}
+	if _, ok := g.Resumption(); ok {
+		t.Errorf("Game.Resumption() returned true for a game without a resume date")
+	}
+}
+
+func TestGameNumberFromGID(t *testing.T) {
+	var testCases = []struct {
+		Gid  string
+		Want int
+		Ok   bool
+	}{
+		{"2016_09_05_kcamlb_minmlb_1", 1, true},
+		{"2016_09_05_kcamlb_minmlb_2", 2, true},
+		{"2016_09_05_kcamlb_minmlb", 0, false},
+		{"2016_09_05_kcamlb_minmlb_x", 0, false},
+	}
+
+	for _, tc := range testCases {
+		got, ok := GameNumberFromGID(tc.Gid)
+		if got != tc.Want || ok != tc.Ok {
+			t.Errorf("GameNumberFromGID(%q) == %v, %v, want %v, %v",
+				tc.Gid, got, ok, tc.Want, tc.Ok)
+		}
+	}
+}
+
+func TestResumedAtBats(t *testing.T) {
+	setup()
+	defer teardown()
+
+	setupResumed(t, true)
+
+	game, err := NewResumedGameService(client, resumedGID, resumedDate())
+	if err != nil {
+		t.Fatalf("NewResumedGameService returned error: %v", err)
+	}
+
+	got, err := game.AtBats()
+	if err != nil {
+		t.Fatalf("Game.AtBats returned error: %v", err)
+	}
+
+	want := &AtBats{
+		[]AtBatInning{
+			{
+				1,
+				[]AtBat{{AtBatSummary: AtBatSummary{1, 502481, 621244, 0, 1, 1, "Groundout", 0, 0}}},
+				[]AtBat{{AtBatSummary: AtBatSummary{2, 621439, 572044, 2, 2, 1, "Strikeout", 0, 0}}},
+				nil,
+				nil,
+			},
+			{
+				2,
+				[]AtBat{
+					{AtBatSummary: AtBatSummary{3, 521692, 621244, 1, 2, 1, "Strikeout", 0, 0}},
+					{AtBatSummary: AtBatSummary{4, 502481, 621244, 0, 0, 1, "Home Run", 0, 1}},
+				},
+				[]AtBat{{AtBatSummary: AtBatSummary{5, 621439, 572044, 0, 1, 1, "Flyout", 0, 1}}},
+				nil,
+				nil,
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Game.AtBats returned %v, want %v", got, want)
+	}
+}
+
+func TestResumedAtBatsNotYetResumed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	setupResumed(t, false)
+
+	game, err := NewResumedGameService(client, resumedGID, resumedDate())
+	if err != nil {
+		t.Fatalf("NewResumedGameService returned error: %v", err)
+	}
+
+	got, err := game.AtBats()
+	if err != nil {
+		t.Fatalf("Game.AtBats returned error: %v", err)
+	}
+
+	if len(got.Innings) != 2 || len(got.Innings[1].Top) != 1 ||
+		got.Innings[1].Top[0].Event != "" {
+		t.Errorf("Game.AtBats returned %v, want the suspended part only", got)
+	}
+}
+
+func TestResumedResourcesNotYetResumed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	// Only the folder of the date on which the game began is published.
+	setupResumed(t, false)
+	folder := "/components/game/mlb/year_2016/month_09/day_04/gid_" + resumedGID + "/"
+	mux.HandleFunc(folder, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Path != folder {
+			http.Error(w, "Not Found", 404)
+			return
+		}
+		serveFile(t, w, "./mock/game_listing.html")
+	})
+	mux.HandleFunc(folder+"linescore.xml", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		serveFile(t, w, "./mock/linescore.xml")
+	})
+	mux.HandleFunc(folder+"players.xml", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		serveFile(t, w, "./mock/players.xml")
+	})
+
+	game, err := NewResumedGameService(client, resumedGID, resumedDate())
+	if err != nil {
+		t.Fatalf("NewResumedGameService returned error: %v", err)
+	}
+
+	ls, err := game.LineScore()
+	if err != nil || ls.Inning == 0 {
+		t.Errorf("Game.LineScore returned %v, %v, want the line score of the first part",
+			ls, err)
+	}
+	players, err := game.Players()
+	if err != nil || len(players.Teams) == 0 {
+		t.Errorf("Game.Players returned %v, %v, want the players of the first part",
+			players, err)
+	}
+	resources, err := game.ListResources()
+	if err != nil || len(resources) == 0 {
+		t.Errorf("Game.ListResources returned %v, %v, want the first part's folder",
+			resources, err)
+	}
+
+	_, err = game.Notifications()
+	testError(t, "Game.Notifications", "HTTP 404", err)
+}
+
+func TestResumedAtBatsErrorHTTP404(t *testing.T) {
+	setup()
+	defer teardown()
+
+	game, err := NewResumedGameService(client, resumedGID, resumedDate())
+	if err != nil {
+		t.Fatalf("NewResumedGameService returned error: %v", err)
+	}
+
+	got, err := game.AtBats()
+	if got != nil {
+		t.Errorf("Game.AtBats returned %v, want nil", got)
+	}
+
+	testError(t, "Game.AtBats", "HTTP 404", err)
+}
+
+func TestNewResumedGameServiceErrorInvalidGID(t *testing.T) {
+	gid := "2016_09_04_kcamlb_minmlb"
+	got, err := NewResumedGameService(client, gid, resumedDate())
+	if got != nil {
+		t.Errorf("NewResumedGameService returned %v, want nil", got)
+	}
+
+	testError(t, "NewResumedGameService", "Could not derive date from id "+gid, err)
+}
+
+func TestGameForTeamResumed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	setupResumed(t, true)
+
+	games, err := client.Gameday(resumedDate()).GameForTeam("KC")
+	if err != nil {
+		t.Fatalf("Gameday.GameForTeam returned error: %v", err)
+	}
+	if len(games) != 2 {
+		t.Fatalf("Gameday.GameForTeam returned %v games, want 2", len(games))
+	}
+
+	got, err := games[0].AtBats()
+	if err != nil {
+		t.Fatalf("Game.AtBats returned error: %v", err)
+	}
+	if len(got.Innings) != 2 || len(got.Innings[0].Top) != 1 ||
+		len(got.Innings[1].Top) != 2 {
+		t.Errorf("Game.AtBats returned %v, want both parts of the game", got)
+	}
+
+	// The second game was postponed from an earlier date and is played in
+	// its own folder.
+	if svc, ok := games[1].(*GameServiceOp); !ok || len(svc.parts) != 0 {
+		t.Errorf("Gameday.GameForTeam returned %+v for a postponed game, want a single part", games[1])
+	}
+}
+
+func TestGameResumed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	setupResumed(t, true)
+
+	game, err := client.Gameday(resumedDate()).Game(resumedGID)
+	if err != nil {
+		t.Fatalf("Gameday.Game returned error: %v", err)
+	}
+
+	got, err := game.AtBats()
+	if err != nil {
+		t.Fatalf("Game.AtBats returned error: %v", err)
+	}
+	if len(got.Innings) != 2 || len(got.Innings[1].Top) != 2 {
+		t.Errorf("Game.AtBats returned %v, want both parts of the game", got)
+	}
+}
@@ -0,0 +1,334 @@
+// Package runexp computes run expectancy and RE24 from MLB Gameday at-bats.
+//
+// A run expectancy Matrix holds, for each of the 24 base-out states, the
+// average number of runs scored from the state to the end of the half
+// inning. Build one from a set of games, such as an archived season, and use
+// it to compute the RE24 of every plate appearance in any game: the change
+// in run expectancy over the plate appearance plus the runs scored on it.
+package runexp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ericdreeves/mlbgameday"
+)
+
+// State identifies one of the 24 base-out states.
+type State struct {
+	// Bases holds a bit for each occupied base: 1 for first, 2 for second
+	// and 4 for third.
+	Bases int
+
+	// Outs is 0, 1 or 2.
+	Outs int
+}
+
+// StateOf returns the base-out state of a game state. It returns false if
+// the half inning is over.
+func StateOf(s mlbgameday.GameState) (State, bool) {
+	if s.Outs >= 3 {
+		return State{}, false
+	}
+
+	st := State{Outs: s.Outs}
+	for i, id := range s.Bases {
+		if id != 0 {
+			st.Bases |= 1 << uint(i)
+		}
+	}
+	return st, true
+}
+
+// index returns the index of the state in a Matrix.
+func (s State) index() int {
+	return s.Outs*8 + s.Bases
+}
+
+// String returns the state in the conventional notation, e.g. "1-3 2 outs".
+func (s State) String() string {
+	return fmt.Sprintf("%v %d outs", basesString(s.Bases), s.Outs)
+}
+
+// basesString returns the occupied bases as, e.g., "1-3" for runners on first
+// and third or "---" for empty bases.
+func basesString(bases int) string {
+	b := []byte("---")
+	for i := uint(0); i < 3; i++ {
+		if bases&(1<<i) != 0 {
+			b[i] = byte('1' + i)
+		}
+	}
+	return string(b)
+}
+
+// parseBases parses bases formatted by basesString.
+func parseBases(s string) (int, error) {
+	if len(s) != 3 {
+		return 0, fmt.Errorf("Invalid bases %v", s)
+	}
+
+	bases := 0
+	for i := uint(0); i < 3; i++ {
+		switch s[i] {
+		case '-':
+		case byte('1' + i):
+			bases |= 1 << i
+		default:
+			return 0, fmt.Errorf("Invalid bases %v", s)
+		}
+	}
+	return bases, nil
+}
+
+// Matrix represents the run expectancy of each base-out state.
+type Matrix struct {
+	// Runs holds the total runs scored from each state to the end of the
+	// half inning, and Count the number of times each state was observed,
+	// indexed by 8*outs + bases.
+	Runs  [24]int
+	Count [24]int
+}
+
+// Build returns the run expectancy matrix of a set of games.
+func Build(games ...*mlbgameday.AtBats) *Matrix {
+	m := new(Matrix)
+	for _, abs := range games {
+		m.Add(abs)
+	}
+	return m
+}
+
+// Add adds the plate appearances of a game to the matrix. The state at the
+// start of every plate appearance is observed, with the runs scored from it
+// to the end of its half inning. At-bats that are not completed plate
+// appearances, and half innings that did not end with three outs, such as
+// those that ended the game, are skipped.
+func (m *Matrix) Add(abs *mlbgameday.AtBats) {
+	type observation struct {
+		state State
+		runs  int
+	}
+
+	var half []observation
+	var last mlbgameday.GameState
+	finish := func() {
+		if last.Outs >= 3 {
+			for _, o := range half {
+				m.Runs[o.state.index()] += runs(last) - o.runs
+				m.Count[o.state.index()]++
+			}
+		}
+		half = half[:0]
+	}
+
+	mlbgameday.WalkGameStates(abs, func(step mlbgameday.GameStep) error {
+		if step.Before.Inning != last.Inning ||
+			step.Before.TopInning != last.TopInning {
+			finish()
+		}
+		last = step.After
+
+		if step.Kind == mlbgameday.PlateAppearanceStep && step.AtBat.IsPlateAppearance() {
+			if st, ok := StateOf(step.Before); ok {
+				half = append(half, observation{st, runs(step.Before)})
+			}
+		}
+		return nil
+	})
+	finish()
+}
+
+// Expectancy returns the average number of runs scored from the state to the
+// end of the half inning, or 0 if the state was never observed.
+func (m *Matrix) Expectancy(s State) float64 {
+	i := s.index()
+	if m.Count[i] == 0 {
+		return 0
+	}
+	return float64(m.Runs[i]) / float64(m.Count[i])
+}
+
+// matrixState is the serialized form of a single state of a Matrix.
+type matrixState struct {
+	Bases      string  `json:"bases"`
+	Outs       int     `json:"outs"`
+	Runs       int     `json:"runs"`
+	Count      int     `json:"count"`
+	Expectancy float64 `json:"expectancy"`
+}
+
+// MarshalJSON encodes the matrix as a list of its 24 states with their runs,
+// counts and run expectancy, e.g.
+//
+//	[{"bases":"1-3","outs":2,"runs":57,"count":120,"expectancy":0.475}, ...]
+func (m *Matrix) MarshalJSON() ([]byte, error) {
+	states := make([]matrixState, 0, 24)
+	for outs := 0; outs < 3; outs++ {
+		for bases := 0; bases < 8; bases++ {
+			s := State{bases, outs}
+			states = append(states, matrixState{
+				Bases:      basesString(bases),
+				Outs:       outs,
+				Runs:       m.Runs[s.index()],
+				Count:      m.Count[s.index()],
+				Expectancy: m.Expectancy(s),
+			})
+		}
+	}
+
+	return json.Marshal(states)
+}
+
+// UnmarshalJSON decodes a matrix encoded by MarshalJSON. The expectancy of
+// each state is ignored in favor of its runs and count.
+func (m *Matrix) UnmarshalJSON(data []byte) error {
+	var states []matrixState
+	if err := json.Unmarshal(data, &states); err != nil {
+		return err
+	}
+
+	*m = Matrix{}
+	for _, ms := range states {
+		bases, err := parseBases(strings.TrimSpace(ms.Bases))
+		if err != nil {
+			return err
+		}
+		if ms.Outs < 0 || ms.Outs > 2 {
+			return fmt.Errorf("Invalid outs %v", ms.Outs)
+		}
+
+		s := State{bases, ms.Outs}
+		m.Runs[s.index()] = ms.Runs
+		m.Count[s.index()] = ms.Count
+	}
+
+	return nil
+}
+
+// PlateAppearance represents the RE24 of a single plate appearance.
+type PlateAppearance struct {
+	AtBat     *mlbgameday.AtBat
+	Inning    int
+	TopInning bool
+
+	// Before and After are the base-out states at the start and end of the
+	// plate appearance, where its start follows any baserunning during it.
+	// After is the zero State if the plate appearance ended the half inning;
+	// EndedInning reports whether it did.
+	Before      State
+	After       State
+	EndedInning bool
+
+	// Runs is the number of runs scored on the plate appearance, excluding
+	// runs scored on baserunning during it.
+	Runs int
+
+	// RE24 is the change in run expectancy over the plate appearance plus
+	// Runs.
+	RE24 float64
+}
+
+// PlayerRE24 represents a player's total RE24 in a game.
+type PlayerRE24 struct {
+	ID   int
+	PA   int
+	RE24 float64
+}
+
+// Report represents the RE24 of the plate appearances in a game and the
+// totals of each batter and pitcher.
+type Report struct {
+	PlateAppearances []PlateAppearance
+
+	// Batters holds the RE24 of each batter, and Pitchers the RE24 allowed
+	// by each pitcher, in the order in which they appeared. A pitcher's RE24
+	// is from the batters' perspective, so good pitching is negative.
+	Batters  []PlayerRE24
+	Pitchers []PlayerRE24
+}
+
+// RE24 returns the RE24 of every plate appearance in a game.
+//
+// Baserunning actions during a plate appearance, such as stolen bases and
+// wild pitches, are not credited to the batter or pitcher. At-bats that are
+// not completed plate appearances are skipped.
+func (m *Matrix) RE24(abs *mlbgameday.AtBats) *Report {
+	r := new(Report)
+	batters, pitchers := make(map[int]int), make(map[int]int)
+	credit := func(players *[]PlayerRE24, index map[int]int, id int, re24 float64) {
+		i, ok := index[id]
+		if !ok {
+			i = len(*players)
+			index[id] = i
+			*players = append(*players, PlayerRE24{ID: id})
+		}
+		(*players)[i].PA++
+		(*players)[i].RE24 += re24
+	}
+
+	// last is the state after the most recent pitch or action of the plate
+	// appearance inProgress. Baserunning during a plate appearance happens
+	// before last, so its RE24 and runs are counted from there.
+	var last mlbgameday.GameState
+	var inProgress *mlbgameday.AtBat
+	mlbgameday.WalkGameStates(abs, func(step mlbgameday.GameStep) error {
+		if step.Kind != mlbgameday.PlateAppearanceStep {
+			last, inProgress = step.After, step.AtBat
+			return nil
+		}
+
+		from := step.Before
+		if step.AtBat == inProgress {
+			from = last
+		}
+		inProgress = nil
+		re24 := m.value(from, step.After)
+
+		ab := step.AtBat
+		if !ab.IsPlateAppearance() {
+			return nil
+		}
+
+		pa := PlateAppearance{
+			AtBat:     ab,
+			Inning:    step.Before.Inning,
+			TopInning: step.Before.TopInning,
+			Runs:      runs(step.After) - runs(from),
+			RE24:      re24,
+		}
+		pa.Before, _ = StateOf(from)
+		var ok bool
+		pa.After, ok = StateOf(step.After)
+		pa.EndedInning = !ok
+		r.PlateAppearances = append(r.PlateAppearances, pa)
+
+		credit(&r.Batters, batters, ab.Batter, re24)
+		credit(&r.Pitchers, pitchers, ab.Pitcher, re24)
+		return nil
+	})
+
+	return r
+}
+
+// value returns the change in run expectancy between two game states plus
+// the runs scored between them.
+func (m *Matrix) value(before, after mlbgameday.GameState) float64 {
+	v := float64(runs(after) - runs(before))
+	if s, ok := StateOf(after); ok {
+		v += m.Expectancy(s)
+	}
+	if s, ok := StateOf(before); ok {
+		v -= m.Expectancy(s)
+	}
+	return v
+}
+
+// runs returns the runs of the batting team in a game state.
+func runs(s mlbgameday.GameState) int {
+	if s.TopInning {
+		return s.AwayRuns
+	}
+	return s.HomeRuns
+}
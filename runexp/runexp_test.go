@@ -0,0 +1,257 @@
+package runexp
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/ericdreeves/mlbgameday"
+	"github.com/ericdreeves/mlbgameday/internal/testutil"
+)
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestBuild(t *testing.T) {
+	m := Build(testutil.AtBats(t))
+
+	var testCases = []struct {
+		State      State
+		Count      int
+		Expectancy float64
+	}{
+		{State{0, 0}, 20, 0.8},
+		{State{1, 0}, 5, 1.8},
+		{State{0, 1}, 12, 0.25},
+		{State{5, 2}, 3, 1},
+		{State{4, 0}, 0, 0},
+	}
+	for _, tc := range testCases {
+		if n := m.Count[tc.State.index()]; n != tc.Count {
+			t.Errorf("Build observed %v %v times, want %v", tc.State, n, tc.Count)
+		}
+		if e := m.Expectancy(tc.State); !approx(e, tc.Expectancy) {
+			t.Errorf("Matrix.Expectancy(%v) returned %v, want %v",
+				tc.State, e, tc.Expectancy)
+		}
+	}
+
+	total := 0
+	for _, runs := range m.Runs[:8] {
+		total += runs
+	}
+	if total != 38 {
+		t.Errorf("Build returned %v runs from states with no outs, want 38", total)
+	}
+}
+
+func TestBuildNotPlateAppearance(t *testing.T) {
+	// Batter 10 singles, and the inning ends on his caught stealing during
+	// the at-bat of batter 13, which is not a plate appearance.
+	top := []mlbgameday.AtBat{
+		{
+			AtBatSummary: mlbgameday.AtBatSummary{Number: 1, Batter: 10, Pitcher: 2, Event: "Single"},
+			Pitches:      []mlbgameday.Pitch{{Des: "In play, no out", Type: "X"}},
+			Runners:      []mlbgameday.Runner{{ID: 10, End: "1B"}},
+		},
+		{
+			AtBatSummary: mlbgameday.AtBatSummary{Number: 2, Batter: 11, Pitcher: 2, Outs: 1,
+				Event: "Strikeout"},
+			Pitches: []mlbgameday.Pitch{{Des: "Swinging Strike", Type: "S"}},
+		},
+		{
+			AtBatSummary: mlbgameday.AtBatSummary{Number: 3, Batter: 12, Pitcher: 2, Outs: 2,
+				Event: "Flyout"},
+			Pitches: []mlbgameday.Pitch{{Des: "In play, out(s)", Type: "X"}},
+		},
+		{
+			AtBatSummary: mlbgameday.AtBatSummary{Number: 4, Batter: 13, Pitcher: 2, Outs: 3,
+				Event: "Caught Stealing 2B"},
+			Pitches: []mlbgameday.Pitch{{Des: "Ball", Type: "B"}},
+			Runners: []mlbgameday.Runner{{ID: 10, Start: "1B"}},
+		},
+	}
+	abs := &mlbgameday.AtBats{Innings: []mlbgameday.AtBatInning{{Number: 1, Top: top}}}
+
+	m := Build(abs)
+	var testCases = []struct {
+		State State
+		Count int
+	}{
+		{State{0, 0}, 1},
+		{State{1, 1}, 1},
+		{State{1, 2}, 0},
+	}
+	for _, tc := range testCases {
+		if n := m.Count[tc.State.index()]; n != tc.Count {
+			t.Errorf("Build observed %v %v times, want %v", tc.State, n, tc.Count)
+		}
+	}
+	if r := m.RE24(abs); len(r.PlateAppearances) != 3 {
+		t.Errorf("Matrix.RE24 returned %v plate appearances, want 3", len(r.PlateAppearances))
+	}
+}
+
+func TestMatrixJSON(t *testing.T) {
+	m := Build(testutil.AtBats(t))
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+
+	var states []map[string]interface{}
+	json.Unmarshal(data, &states)
+	if len(states) != 24 || states[13]["bases"] != "1-3" ||
+		states[13]["outs"] != 1.0 || states[13]["expectancy"] != 1.5 {
+		t.Errorf("json.Marshal returned %s, want 24 states with 1-3 1 outs at 13", data)
+	}
+
+	got := new(Matrix)
+	if err := json.Unmarshal(data, got); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, m) {
+		t.Errorf("json.Unmarshal returned %v, want %v", got, m)
+	}
+}
+
+func TestMatrixJSONError(t *testing.T) {
+	err := json.Unmarshal([]byte(`[{"bases":"12","outs":0}]`), new(Matrix))
+	if err == nil || err.Error() != "Invalid bases 12" {
+		t.Errorf("json.Unmarshal returned error %v, want Invalid bases 12", err)
+	}
+
+	err = json.Unmarshal([]byte(`[{"bases":"---","outs":3}]`), new(Matrix))
+	if err == nil || err.Error() != "Invalid outs 3" {
+		t.Errorf("json.Unmarshal returned error %v, want Invalid outs 3", err)
+	}
+}
+
+func TestRE24(t *testing.T) {
+	abs := testutil.AtBats(t)
+	r := Build(abs).RE24(abs)
+
+	if n := len(r.PlateAppearances); n != 88 {
+		t.Fatalf("Matrix.RE24 returned %v plate appearances, want 88", n)
+	}
+
+	// A three-run home run with runners on first and third and two outs.
+	hr := r.PlateAppearances[40]
+	if hr.AtBat.Number != 41 || hr.Before != (State{5, 2}) ||
+		hr.After != (State{0, 2}) || hr.Runs != 3 || !approx(hr.RE24, 2) {
+		t.Errorf("Matrix.RE24 returned %+v for at-bat 41, want 1-3 to --- with 2 outs, 3 runs and RE24 2",
+			hr)
+	}
+
+	// A walk during which the runner on first stole second and took third
+	// on an error; the steal is not credited to the batter.
+	walk := r.PlateAppearances[39]
+	if walk.Before != (State{4, 2}) || walk.After != (State{5, 2}) || !approx(walk.RE24, 1) {
+		t.Errorf("Matrix.RE24 returned %+v for at-bat 40, want --3 to 1-3 with 2 outs and RE24 1",
+			walk)
+	}
+
+	if !r.PlateAppearances[2].EndedInning {
+		t.Errorf("Matrix.RE24 did not end the inning on a double play")
+	}
+
+	if b := r.Batters[2]; b.ID != 543333 || b.PA != 5 || !approx(b.RE24, 2.25) {
+		t.Errorf("Matrix.RE24 returned batter %+v, want 543333 with 5 PA and RE24 2.25", b)
+	}
+	if p := r.Pitchers[0]; p.ID != 621244 || p.PA != 23 || !approx(p.RE24, 1.5) {
+		t.Errorf("Matrix.RE24 returned pitcher %+v, want 621244 with 23 PA and RE24 1.5", p)
+	}
+}
+
+func TestRE24BetweenPitches(t *testing.T) {
+	// Batter 10 triples and scores on a wild pitch to batter 11, who strikes
+	// out; batter 12 walks and is picked off during the plate appearance of
+	// batter 13, who grounds out.
+	top := []mlbgameday.AtBat{
+		{
+			AtBatSummary: mlbgameday.AtBatSummary{Number: 1, Batter: 10, Pitcher: 2, Event: "Triple"},
+			Pitches:      []mlbgameday.Pitch{{Des: "In play, no out", Type: "X", EventNum: 1}},
+			EventNum:     2,
+			Runners:      []mlbgameday.Runner{{ID: 10, End: "3B", EventNum: 2}},
+		},
+		{
+			AtBatSummary: mlbgameday.AtBatSummary{Number: 2, Batter: 11, Pitcher: 2, Outs: 1,
+				Event: "Strikeout", AwayTeamRuns: 1},
+			Pitches: []mlbgameday.Pitch{
+				{Des: "Ball In Dirt", Type: "B", EventNum: 3},
+				{Des: "Swinging Strike", Type: "S", EventNum: 5},
+				{Des: "Swinging Strike", Type: "S", EventNum: 6},
+				{Des: "Swinging Strike", Type: "S", EventNum: 7},
+			},
+			EventNum: 8,
+			Runners: []mlbgameday.Runner{
+				{ID: 10, Start: "3B", EventNum: 4, Score: "T", Earned: "T"},
+			},
+		},
+		{
+			AtBatSummary: mlbgameday.AtBatSummary{Number: 3, Batter: 12, Pitcher: 2, Outs: 1,
+				Event: "Walk", AwayTeamRuns: 1},
+			Pitches: []mlbgameday.Pitch{
+				{Des: "Ball", Type: "B", EventNum: 9},
+				{Des: "Ball", Type: "B", EventNum: 10},
+				{Des: "Ball", Type: "B", EventNum: 11},
+				{Des: "Ball", Type: "B", EventNum: 12},
+			},
+			EventNum: 13,
+			Runners:  []mlbgameday.Runner{{ID: 12, End: "1B", EventNum: 13}},
+		},
+		{
+			AtBatSummary: mlbgameday.AtBatSummary{Number: 4, Batter: 13, Pitcher: 2, Outs: 3,
+				Event: "Groundout", AwayTeamRuns: 1},
+			Pitches: []mlbgameday.Pitch{
+				{Des: "Ball", Type: "B", EventNum: 14},
+				{Des: "In play, out(s)", Type: "X", EventNum: 16},
+			},
+			EventNum: 17,
+			Runners: []mlbgameday.Runner{
+				{ID: 12, Start: "1B", EventNum: 15},
+				{ID: 13, EventNum: 17},
+			},
+		},
+	}
+	actions := []mlbgameday.Action{
+		{Event: "Wild Pitch", Player: 10, Outs: 0, EventNum: 4, AwayTeamRuns: 1},
+		{Event: "Pickoff 1B", Player: 12, Outs: 2, EventNum: 15, AwayTeamRuns: 1},
+	}
+	abs := &mlbgameday.AtBats{Innings: []mlbgameday.AtBatInning{
+		{Number: 1, Top: top, TopActions: actions},
+	}}
+
+	m := Build(testutil.AtBats(t))
+	r := m.RE24(abs)
+	if len(r.PlateAppearances) != 4 {
+		t.Fatalf("Matrix.RE24 returned %v plate appearances, want 4", len(r.PlateAppearances))
+	}
+
+	// The run scored on the wild pitch is not the strikeout's.
+	k := r.PlateAppearances[1]
+	want := m.Expectancy(State{0, 1}) - m.Expectancy(State{0, 0})
+	if k.Before != (State{0, 0}) || k.After != (State{0, 1}) || k.Runs != 0 ||
+		!approx(k.RE24, want) {
+		t.Errorf("Matrix.RE24 returned %+v for the strikeout, want --- to --- with 1 out, no runs and RE24 %v",
+			k, want)
+	}
+
+	// The groundout starts with 2 outs after the pickoff and ends the inning.
+	out := r.PlateAppearances[3]
+	want = -m.Expectancy(State{0, 2})
+	if out.Before != (State{0, 2}) || !out.EndedInning || out.Runs != 0 ||
+		!approx(out.RE24, want) {
+		t.Errorf("Matrix.RE24 returned %+v for the groundout, want --- with 2 outs ending the inning and RE24 %v",
+			out, want)
+	}
+}
+
+func TestStateString(t *testing.T) {
+	if s := (State{5, 2}).String(); s != "1-3 2 outs" {
+		t.Errorf("State.String returned %v, want 1-3 2 outs", s)
+	}
+}
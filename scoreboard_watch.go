@@ -0,0 +1,146 @@
+package mlbgameday
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ScoreboardWatcher polls the scoreboard of a day and reports changes to
+// each of its games as events.
+type ScoreboardWatcher struct {
+	gameday GamedayService
+	opts    WatchOptions
+
+	mu  sync.Mutex
+	err error
+
+	// The games on the scoreboard as of the last poll, by GID.
+	polled bool
+	games  map[string]Game
+}
+
+// NewScoreboardWatcher returns a new ScoreboardWatcher for the games of the
+// provided day. opts may be nil; see WatchOptions.
+func NewScoreboardWatcher(gameday GamedayService, opts *WatchOptions) *ScoreboardWatcher {
+	return &ScoreboardWatcher{
+		gameday: gameday,
+		opts:    opts.withDefaults(),
+		games:   make(map[string]Game),
+	}
+}
+
+// Watch polls the scoreboard until every game on it is over or ctx is done
+// and returns a channel of the events observed: GameStarted, GameDelayed,
+// InningChange, ScoreChange, LeadChange and GameFinal. The channel is closed
+// after the last game's final event or once ctx is done. A
+// ScoreboardWatcher should only be watched once.
+//
+// An empty scoreboard is polled again at the idle interval, since the games
+// of a day may not be posted yet; watch with a ctx that has a deadline to
+// give up on a day without games.
+func (w *ScoreboardWatcher) Watch(ctx context.Context) <-chan Event {
+	return watch(ctx, w.poll)
+}
+
+// Err returns the error of the most recent poll, if it failed. Failed polls
+// are retried at the idle interval.
+func (w *ScoreboardWatcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+// poll retrieves the scoreboard once and returns the events since the
+// previous poll, the interval until the next poll and whether every game is
+// over. No game is over until the scoreboard has games.
+func (w *ScoreboardWatcher) poll() ([]Event, time.Duration, bool) {
+	sb, err := w.gameday.Scoreboard()
+	w.mu.Lock()
+	w.err = err
+	w.mu.Unlock()
+	if err != nil {
+		return nil, w.opts.Idle, false
+	}
+
+	emit := w.polled || w.opts.Replay
+	w.polled = true
+
+	var events []Event
+	over := len(sb.Games) > 0
+	interval := w.opts.Idle
+	for _, g := range sb.Games {
+		if emit {
+			events = append(events, diffGames(w.games[g.GID], g)...)
+		}
+		w.games[g.GID] = g
+
+		if !isOver(g.Status) {
+			over = false
+		}
+		if g.Status == "In Progress" {
+			if gi := w.opts.interval(&g); gi < interval {
+				interval = gi
+			}
+		}
+	}
+
+	return events, interval, over
+}
+
+// diffGames returns the events between two scoreboard entries of a game.
+func diffGames(prev, cur Game) []Event {
+	var events []Event
+	add := func(t EventType) *Event {
+		g := cur
+		events = append(events, Event{
+			Type: t, GID: cur.GID, Inning: cur.Inning,
+			TopInning: cur.TopInning, Status: cur.Status, Game: &g,
+		})
+		return &events[len(events)-1]
+	}
+
+	if !hasStarted(prev.Status) && hasStarted(cur.Status) &&
+		!isOver(cur.Status) {
+		add(GameStarted)
+	}
+
+	if strings.HasPrefix(cur.Status, "Delayed") &&
+		!strings.HasPrefix(prev.Status, "Delayed") {
+		add(GameDelayed)
+	}
+
+	if cur.Inning > 0 && halfInning(cur.Inning, cur.TopInning) >
+		halfInning(prev.Inning, prev.TopInning) {
+		add(InningChange)
+	}
+
+	prevRuns := prev.AwayTeamRuns + prev.HomeTeamRuns
+	if runs := cur.AwayTeamRuns + cur.HomeTeamRuns; runs != prevRuns {
+		add(ScoreChange).Runs = runs - prevRuns
+	}
+
+	if l := leader(&cur); l != "" && l != leader(&prev) {
+		add(LeadChange)
+	}
+
+	if isFinal(cur.Status) && !isFinal(prev.Status) {
+		add(GameFinal)
+	}
+
+	return events
+}
+
+// leader returns the abbreviation of the team leading the game, or "" if the
+// game is tied.
+func leader(g *Game) string {
+	switch {
+	case g.AwayTeamRuns > g.HomeTeamRuns:
+		return g.AwayNameAbbrev
+	case g.HomeTeamRuns > g.AwayTeamRuns:
+		return g.HomeNameAbbrev
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,188 @@
+package mlbgameday
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeGameday is a GamedayService that returns the next scoreboard each
+// time its scoreboard is retrieved.
+type fakeGameday struct {
+	GamedayService
+
+	mu          sync.Mutex
+	scoreboards []*Scoreboard
+	err         error
+	n           int
+}
+
+func (s *fakeGameday) Scoreboard() (*Scoreboard, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.n < len(s.scoreboards) {
+		s.n++
+	}
+	return s.scoreboards[s.n-1], nil
+}
+
+func watchGame(gid, status string, inning int, top string, away, home int) Game {
+	return Game{
+		GID: gid, AwayNameAbbrev: "KC", HomeNameAbbrev: "MIN",
+		Status: status, Inning: inning, TopInning: top,
+		AwayTeamRuns: away, HomeTeamRuns: home,
+	}
+}
+
+func watchScoreboards() []*Scoreboard {
+	const a, b = "a", "b"
+	return []*Scoreboard{
+		{[]Game{
+			watchGame(a, "Preview", 0, "", 0, 0),
+			watchGame(b, "Preview", 0, "", 0, 0),
+		}},
+		{[]Game{
+			watchGame(a, "In Progress", 1, "Y", 0, 0),
+			watchGame(b, "Delayed Start", 0, "", 0, 0),
+		}},
+		{[]Game{
+			watchGame(a, "In Progress", 1, "N", 1, 0),
+			watchGame(b, "In Progress", 1, "Y", 0, 0),
+		}},
+		{[]Game{
+			watchGame(a, "In Progress", 2, "Y", 1, 2),
+			watchGame(b, "Final", 9, "N", 3, 2),
+		}},
+		{[]Game{
+			watchGame(a, "Final", 2, "Y", 1, 2),
+			watchGame(b, "Final", 9, "N", 3, 2),
+		}},
+	}
+}
+
+type scoreboardEvent struct {
+	Type   EventType
+	GID    string
+	Inning int
+	Runs   int
+	Leader string
+}
+
+func collectScoreboardEvents(events <-chan Event) []scoreboardEvent {
+	var got []scoreboardEvent
+	for e := range events {
+		got = append(got, scoreboardEvent{
+			e.Type, e.GID, e.Inning, e.Runs, leader(e.Game),
+		})
+	}
+	return got
+}
+
+func TestScoreboardWatcher(t *testing.T) {
+	gameday := &fakeGameday{scoreboards: watchScoreboards()}
+	w := NewScoreboardWatcher(gameday, watchOptions())
+
+	got := collectScoreboardEvents(w.Watch(context.Background()))
+
+	want := []scoreboardEvent{
+		{GameStarted, "a", 1, 0, ""},
+		{InningChange, "a", 1, 0, ""},
+		{GameDelayed, "b", 0, 0, ""},
+
+		{InningChange, "a", 1, 0, "KC"},
+		{ScoreChange, "a", 1, 1, "KC"},
+		{LeadChange, "a", 1, 0, "KC"},
+		{GameStarted, "b", 1, 0, ""},
+		{InningChange, "b", 1, 0, ""},
+
+		{InningChange, "a", 2, 0, "MIN"},
+		{ScoreChange, "a", 2, 2, "MIN"},
+		{LeadChange, "a", 2, 0, "MIN"},
+		{InningChange, "b", 9, 0, "KC"},
+		{ScoreChange, "b", 9, 5, "KC"},
+		{LeadChange, "b", 9, 0, "KC"},
+		{GameFinal, "b", 9, 0, "KC"},
+
+		{GameFinal, "a", 2, 0, "MIN"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ScoreboardWatcher.Watch emitted\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestScoreboardWatcherNotPosted(t *testing.T) {
+	// The games of the day are posted after the watch starts.
+	gameday := &fakeGameday{scoreboards: append(
+		[]*Scoreboard{{}, {}}, watchScoreboards()[3:]...)}
+	w := NewScoreboardWatcher(gameday, watchOptions())
+
+	got := collectScoreboardEvents(w.Watch(context.Background()))
+
+	want := []scoreboardEvent{
+		{GameStarted, "a", 2, 0, "MIN"},
+		{InningChange, "a", 2, 0, "MIN"},
+		{ScoreChange, "a", 2, 3, "MIN"},
+		{LeadChange, "a", 2, 0, "MIN"},
+		{InningChange, "b", 9, 0, "KC"},
+		{ScoreChange, "b", 9, 5, "KC"},
+		{LeadChange, "b", 9, 0, "KC"},
+		{GameFinal, "b", 9, 0, "KC"},
+
+		{GameFinal, "a", 2, 0, "MIN"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ScoreboardWatcher.Watch emitted\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestScoreboardWatcherOffDay(t *testing.T) {
+	gameday := &fakeGameday{scoreboards: []*Scoreboard{{}}}
+	w := NewScoreboardWatcher(gameday, watchOptions())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if got := collectScoreboardEvents(w.Watch(ctx)); len(got) != 0 {
+		t.Errorf("ScoreboardWatcher.Watch emitted %v, want no events", got)
+	}
+	if ctx.Err() == nil {
+		t.Errorf("ScoreboardWatcher.Watch closed before the deadline")
+	}
+}
+
+func TestScoreboardWatcherError(t *testing.T) {
+	want := errors.New("HTTP 404")
+	gameday := &fakeGameday{err: want}
+	w := NewScoreboardWatcher(gameday, watchOptions())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := w.Watch(ctx)
+
+	for i := 0; i < 100 && w.Err() == nil; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	if err := w.Err(); err != want {
+		t.Errorf("ScoreboardWatcher.Err() == %v, want %v", err, want)
+	}
+
+	cancel()
+	if got := collectScoreboardEvents(events); len(got) != 0 {
+		t.Errorf("ScoreboardWatcher.Watch emitted %v, want no events", got)
+	}
+}
+
+func TestDiffGamesTied(t *testing.T) {
+	prev := watchGame("a", "In Progress", 5, "N", 2, 1)
+	cur := watchGame("a", "In Progress", 5, "N", 2, 2)
+
+	got := diffGames(prev, cur)
+	if len(got) != 1 || got[0].Type != ScoreChange || got[0].Runs != 1 {
+		t.Errorf("diffGames returned %v, want a single ScoreChange", got)
+	}
+}
@@ -0,0 +1,135 @@
+package mlbgameday
+
+import (
+	"context"
+	"time"
+)
+
+// Day represents a single day of Gameday data: the GamedayService for the
+// day and its scoreboard. Err is set if the scoreboard could not be
+// retrieved.
+type Day struct {
+	Date       time.Time
+	Gameday    GamedayService
+	Scoreboard *Scoreboard
+	Err        error
+}
+
+// RangeOptions configures iteration over a range of days.
+type RangeOptions struct {
+	// Sport of the games to retrieve. Defaults to MLB.
+	Sport Sport
+
+	// SkipOffDays omits days without any games, including days for which
+	// the MLB Gameday API has no folder.
+	SkipOffDays bool
+
+	// Parallelism is the maximum number of scoreboards retrieved at once.
+	// Defaults to 1.
+	Parallelism int
+}
+
+// Season returns a channel of every day of the provided season, from
+// February 1 through November 30, which spans spring training through the
+// postseason. See Range.
+func (c *Client) Season(ctx context.Context, year int, opts *RangeOptions) <-chan Day {
+	from := NewGameDate(year, time.February, 1)
+	to := NewGameDate(year, time.November, 30)
+
+	return c.Range(ctx, from.Time(), to.Time(), opts)
+}
+
+// Range returns a channel of every day from the date of from through the
+// date of to, inclusive, in date order. The channel is closed after the last
+// day or once ctx is done. Scoreboards are retrieved concurrently, up to
+// opts.Parallelism at a time, while days are delivered in order.
+func (c *Client) Range(ctx context.Context, from, to time.Time, opts *RangeOptions) <-chan Day {
+	if opts == nil {
+		opts = &RangeOptions{}
+	}
+	sport := opts.Sport
+	if sport == "" {
+		sport = MLB
+	}
+	n := opts.Parallelism
+	if n < 1 {
+		n = 1
+	}
+
+	dates := datesBetween(from, to)
+	results := make([]chan Day, len(dates))
+	for i := range results {
+		results[i] = make(chan Day, 1)
+	}
+
+	// sem bounds the number of days that have been requested but not yet
+	// delivered, so a slow consumer also slows retrieval.
+	sem := make(chan struct{}, n)
+	done := make(chan struct{})
+
+	go func() {
+		for i, date := range dates {
+			select {
+			case sem <- struct{}{}:
+			case <-done:
+				return
+			}
+
+			go func(i int, date time.Time) {
+				gameday := c.SportGameday(sport, date)
+				sb, err := gameday.Scoreboard()
+				results[i] <- Day{date, gameday, sb, err}
+			}(i, date)
+		}
+	}()
+
+	out := make(chan Day)
+	go func() {
+		defer close(out)
+		defer close(done)
+
+		for _, result := range results {
+			var day Day
+			select {
+			case day = <-result:
+			case <-ctx.Done():
+				return
+			}
+			<-sem
+
+			if opts.SkipOffDays && isOffDay(day) {
+				continue
+			}
+
+			select {
+			case out <- day:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// isOffDay returns whether no games were played on the day.
+func isOffDay(day Day) bool {
+	if e, ok := day.Err.(*HTTPError); ok && e.StatusCode == 404 {
+		return true
+	}
+
+	return day.Err == nil && len(day.Scoreboard.Games) == 0
+}
+
+// datesBetween returns midnight ET of each date from the date of from
+// through the date of to, inclusive.
+func datesBetween(from, to time.Time) []time.Time {
+	end := DateOf(to)
+
+	var dates []time.Time
+	for d := DateOf(from); !d.After(end); d = d.AddDays(1) {
+		dates = append(dates, d.Time())
+	}
+
+	return dates
+}
@@ -0,0 +1,127 @@
+package mlbgameday
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func setupRange(t *testing.T) {
+	data, err := ioutil.ReadFile("./mock/miniscoreboard.xml")
+	if err != nil {
+		t.Fatal("Could not read data file")
+	}
+
+	for _, day := range []string{"05", "07"} {
+		path := "/components/game/mlb/year_2016/month_09/day_" + day +
+			"/miniscoreboard.xml"
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			fmt.Fprint(w, string(data))
+		})
+	}
+
+	path := "/components/game/mlb/year_2016/month_09/day_04/miniscoreboard.xml"
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `<games date="20160904"></games>`)
+	})
+}
+
+func TestRange(t *testing.T) {
+	setup()
+	defer teardown()
+	setupRange(t)
+
+	l, _ := time.LoadLocation("America/New_York")
+	from := time.Date(2016, 9, 4, 0, 0, 0, 0, l)
+	to := time.Date(2016, 9, 7, 0, 0, 0, 0, l)
+
+	var testCases = []struct {
+		Opts  RangeOptions
+		Days  []int
+		Games []int
+		Errs  []string
+	}{
+		{
+			RangeOptions{},
+			[]int{4, 5, 6, 7}, []int{0, 2, 0, 2}, []string{"", "", "HTTP 404", ""},
+		},
+		{
+			RangeOptions{SkipOffDays: true, Parallelism: 3},
+			[]int{5, 7}, []int{2, 2}, []string{"", ""},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run("", func(t *testing.T) {
+			var days, games []int
+			var errs []string
+			for day := range client.Range(context.Background(), from, to, &tc.Opts) {
+				days = append(days, day.Date.Day())
+				if day.Err != nil {
+					errs = append(errs, day.Err.Error())
+					games = append(games, 0)
+					continue
+				}
+				errs = append(errs, "")
+				games = append(games, len(day.Scoreboard.Games))
+			}
+
+			if fmt.Sprint(days) != fmt.Sprint(tc.Days) {
+				t.Errorf("Client.Range returned days %v, want %v", days, tc.Days)
+			}
+			if fmt.Sprint(games) != fmt.Sprint(tc.Games) {
+				t.Errorf("Client.Range returned games %v, want %v", games, tc.Games)
+			}
+			if fmt.Sprint(errs) != fmt.Sprint(tc.Errs) {
+				t.Errorf("Client.Range returned errors %q, want %q", errs, tc.Errs)
+			}
+		})
+	}
+}
+
+func TestRangeCancel(t *testing.T) {
+	setup()
+	defer teardown()
+	setupRange(t)
+
+	l, _ := time.LoadLocation("America/New_York")
+	from := time.Date(2016, 9, 4, 0, 0, 0, 0, l)
+	to := time.Date(2016, 9, 30, 0, 0, 0, 0, l)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	days := client.Range(ctx, from, to, &RangeOptions{Parallelism: 4})
+
+	<-days
+	cancel()
+
+	n := 0
+	for range days {
+		n++
+	}
+	if n > 4 {
+		t.Errorf("Client.Range delivered %v days after cancel, want at most 4", n)
+	}
+}
+
+func TestSeason(t *testing.T) {
+	setup()
+	defer teardown()
+	setupRange(t)
+
+	opts := &RangeOptions{SkipOffDays: true, Parallelism: 8}
+
+	var got []string
+	for day := range client.Season(context.Background(), 2016, opts) {
+		got = append(got, day.Date.Format("2006-01-02"))
+	}
+
+	want := "[2016-09-05 2016-09-07]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("Client.Season returned days %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,71 @@
+package mlbgameday
+
+import "strings"
+
+// Sport identifies a league level for which the MLB Gameday API publishes
+// game data. Each sport has its own folder under components/game/ and its
+// code is the suffix of the team codes in a GID, e.g. "kcamlb".
+type Sport string
+
+// Sports with game data published by the MLB Gameday API.
+const (
+	MLB Sport = "mlb" // Major League Baseball, including spring training
+	AAA Sport = "aaa" // Triple-A
+	AAX Sport = "aax" // Double-A
+	AFA Sport = "afa" // Class A Advanced
+	AFX Sport = "afx" // Class A
+	ASX Sport = "asx" // Class A Short Season
+	ROK Sport = "rok" // Rookie
+	WIN Sport = "win" // Winter leagues
+	INT Sport = "int" // International play
+)
+
+// statsSportIDs maps each sport to its MLB Stats API sport ID.
+var statsSportIDs = map[Sport]int{
+	MLB: 1,
+	AAA: 11,
+	AAX: 12,
+	AFA: 13,
+	AFX: 14,
+	ASX: 15,
+	ROK: 16,
+	WIN: 17,
+	INT: 51,
+}
+
+// SportFromGID returns the sport of the game referenced by the provided game
+// ID, inferred from the suffix of its team codes. It returns false if the
+// teams do not share a known sport.
+func SportFromGID(gid string) (Sport, bool) {
+	toks := strings.Split(gid, "_")
+	if len(toks) != 6 || len(toks[3]) < 3 || len(toks[4]) < 3 {
+		return "", false
+	}
+
+	away := Sport(toks[3][len(toks[3])-3:])
+	home := Sport(toks[4][len(toks[4])-3:])
+	if away != home {
+		return "", false
+	}
+	if _, ok := statsSportIDs[away]; !ok {
+		return "", false
+	}
+
+	return away, true
+}
+
+// path returns the URL path to the Gameday data for this sport.
+func (s Sport) path() string {
+	if s == "" {
+		s = MLB
+	}
+	return "components/game/" + string(s) + "/"
+}
+
+// statsID returns the MLB Stats API sport ID for this sport.
+func (s Sport) statsID() int {
+	if id, ok := statsSportIDs[s]; ok {
+		return id
+	}
+	return statsSportIDs[MLB]
+}
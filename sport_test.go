@@ -0,0 +1,28 @@
+package mlbgameday
+
+import "testing"
+
+func TestSportFromGID(t *testing.T) {
+	var testCases = []struct {
+		GID   string
+		Sport Sport
+		OK    bool
+	}{
+		{"2016_09_05_kcamlb_minmlb_1", MLB, true},
+		{"2016_08_20_omaaaa_iowaaa_2", AAA, true},
+		{"2016_07_04_nwaaax_tulaax_1", AAX, true},
+		{"2016_03_10_nyamlb_usaint_1", "", false},
+		{"2016_09_05_kcaxyz_minxyz_1", "", false},
+		{"invalid_gid", "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.GID, func(t *testing.T) {
+			sport, ok := SportFromGID(tc.GID)
+			if sport != tc.Sport || ok != tc.OK {
+				t.Errorf("SportFromGID(%q) == %q, %v, want %q, %v",
+					tc.GID, sport, ok, tc.Sport, tc.OK)
+			}
+		})
+	}
+}
@@ -0,0 +1,421 @@
+package mlbgameday
+
+import "strings"
+
+// Bases represents the runners on first, second and third base by player ID.
+// An empty base is 0.
+type Bases [3]int
+
+// BaseState returns the occupied bases in the encoding of Game.BaseState.
+func (b Bases) BaseState() int {
+	return baseState(b[0] != 0, b[1] != 0, b[2] != 0)
+}
+
+// Runners returns the number of runners on base.
+func (b Bases) Runners() int {
+	n := 0
+	for _, id := range b {
+		if id != 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// baseIndex returns the index in Bases of a base such as 2B, or -1 for any
+// other value, such as the empty start of a batter or end of a runner who
+// scored or was put out.
+func baseIndex(base string) int {
+	switch base {
+	case "1B":
+		return 0
+	case "2B":
+		return 1
+	case "3B":
+		return 2
+	}
+	return -1
+}
+
+// TeamState represents a team's players at a point in a game.
+type TeamState struct {
+	// Pitcher is the team's current pitcher.
+	Pitcher int
+
+	// Batter is the team's batter at the plate or, while the team is in the
+	// field, its most recent batter. Slot is the batter's place in the
+	// batting order, from 1 to 9, or 0 before the team has batted.
+	Batter int
+	Slot   int
+}
+
+// GameState represents the state of a game between two pitches, actions or
+// plate appearances.
+type GameState struct {
+	Inning    int
+	TopInning bool
+	Outs      int
+	Balls     int
+	Strikes   int
+	Bases     Bases
+	AwayRuns  int
+	HomeRuns  int
+	Away      TeamState
+	Home      TeamState
+}
+
+// Offense returns the state of the batting team.
+func (s GameState) Offense() TeamState {
+	return *s.team(true)
+}
+
+// Defense returns the state of the fielding team.
+func (s GameState) Defense() TeamState {
+	return *s.team(false)
+}
+
+// team returns the state of the batting team if offense is true, and the
+// fielding team otherwise.
+func (s *GameState) team(offense bool) *TeamState {
+	if s.TopInning == offense {
+		return &s.Away
+	}
+	return &s.Home
+}
+
+// StepKind identifies the kind of step reported by a GameStep.
+type StepKind int
+
+// Kinds of steps walked by WalkGameStates.
+const (
+	PitchStep           StepKind = iota // A pitch was thrown
+	ActionStep                          // An action, such as a stolen base or substitution, occurred
+	PlateAppearanceStep                 // A plate appearance ended
+)
+
+var stepKindNames = []string{
+	"PitchStep",
+	"ActionStep",
+	"PlateAppearanceStep",
+}
+
+// String returns the name of the step kind, e.g. "PitchStep".
+func (k StepKind) String() string {
+	if k < 0 || int(k) >= len(stepKindNames) {
+		return "Unknown"
+	}
+	return stepKindNames[k]
+}
+
+// GameStep represents a pitch, action or plate appearance and the state of
+// the game before and after it.
+type GameStep struct {
+	Kind StepKind
+
+	// AtBat is the plate appearance during which a pitch or action occurred,
+	// or that ended. It is nil for actions that occurred after the last
+	// plate appearance of a half inning.
+	AtBat *AtBat
+
+	// Pitch is the pitch thrown for a PitchStep.
+	Pitch *Pitch
+
+	// Action is the action that occurred for an ActionStep.
+	Action *Action
+
+	// Runners are the runner movements applied by the step.
+	Runners []Runner
+
+	Before GameState
+	After  GameState
+}
+
+// WalkGameStates replays the at-bats of a game in order and calls fn for
+// every pitch and action, each followed by the plate appearance in which it
+// occurred, with the state of the game before and after it.
+//
+// The Before state of a plate appearance is the state at its first pitch,
+// with its batter and pitcher; its After state includes the result of the
+// plate appearance and of every pitch and action during it. Runners who move on a
+// pitch or action, such as a stolen base, move at that step; all others
+// move when the plate appearance ends. Outs and the score are reconciled
+// with those of the at-bat when it ends.
+//
+// Lineup slots are derived from the order in which each team's batters come
+// to the plate; a batter who comes to the plate again, after the previous
+// half inning ended during his plate appearance, keeps his slot.
+//
+// Walking stops at the first error returned by fn, which WalkGameStates
+// returns.
+func WalkGameStates(abs *AtBats, fn func(GameStep) error) error {
+	m := &stateMachine{fn: fn}
+	for i := range abs.Innings {
+		inning := &abs.Innings[i]
+		err := m.halfInning(inning.Number, true, inning.Top, inning.TopActions)
+		if err != nil {
+			return err
+		}
+		err = m.halfInning(inning.Number, false, inning.Bottom, inning.BottomActions)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GameSteps returns every step of a game. See WalkGameStates.
+func GameSteps(abs *AtBats) []GameStep {
+	var steps []GameStep
+	WalkGameStates(abs, func(step GameStep) error {
+		steps = append(steps, step)
+		return nil
+	})
+
+	return steps
+}
+
+// stateMachine tracks the state of a game walked by WalkGameStates.
+type stateMachine struct {
+	state GameState
+	fn    func(GameStep) error
+}
+
+// halfInning walks the at-bats and actions of a half inning.
+func (m *stateMachine) halfInning(inning int, top bool, abs []AtBat, actions []Action) error {
+	if len(abs) == 0 && len(actions) == 0 {
+		return nil
+	}
+
+	s := &m.state
+	s.Inning, s.TopInning = inning, top
+	s.Outs, s.Balls, s.Strikes = 0, 0, 0
+	s.Bases = Bases{}
+
+	// Each action occurs during the first at-bat whose result follows it.
+	byAtBat := make([][]*Action, len(abs)+1)
+	for i := range actions {
+		j := 0
+		for j < len(abs) && abs[j].EventNum < actions[i].EventNum {
+			j++
+		}
+		byAtBat[j] = append(byAtBat[j], &actions[i])
+	}
+
+	for i := range abs {
+		if err := m.plateAppearance(&abs[i], byAtBat[i]); err != nil {
+			return err
+		}
+	}
+	for _, a := range byAtBat[len(abs)] {
+		if err := m.fn(m.action(nil, a)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// plateAppearance walks the pitches and actions of an at-bat, ordered by
+// event number, followed by the at-bat itself. Actions before the first
+// pitch, such as pitching changes, are walked before the plate appearance
+// begins.
+func (m *stateMachine) plateAppearance(ab *AtBat, actions []*Action) error {
+	s := &m.state
+	s.Balls, s.Strikes = 0, 0
+
+	offense := s.team(true)
+	if offense.Batter != ab.Batter {
+		offense.Batter = ab.Batter
+		offense.Slot = offense.Slot%9 + 1
+	}
+
+	moved := make(map[int]bool)
+	a := 0
+	for a < len(actions) && (len(ab.Pitches) == 0 ||
+		actions[a].EventNum < ab.Pitches[0].EventNum) {
+		if err := m.fn(m.action(ab, actions[a])); err != nil {
+			return err
+		}
+		moved[actions[a].EventNum] = true
+		a++
+	}
+
+	defense := s.team(false)
+	if defense.Pitcher == 0 || !hasPitchingChange(actions[a:]) {
+		defense.Pitcher = ab.Pitcher
+	}
+	before := *s
+
+	p := 0
+	for p < len(ab.Pitches) || a < len(actions) {
+		var step GameStep
+		if a < len(actions) && (p == len(ab.Pitches) ||
+			actions[a].EventNum < ab.Pitches[p].EventNum) {
+			step = m.action(ab, actions[a])
+			moved[actions[a].EventNum] = true
+			a++
+		} else {
+			step = m.pitch(ab, &ab.Pitches[p])
+			moved[ab.Pitches[p].EventNum] = true
+			p++
+		}
+
+		if err := m.fn(step); err != nil {
+			return err
+		}
+	}
+
+	var runners []Runner
+	for _, r := range ab.Runners {
+		if r.EventNum == 0 || r.EventNum == ab.EventNum || !moved[r.EventNum] {
+			runners = append(runners, r)
+		}
+	}
+	m.advance(runners)
+	s.Outs = ab.Outs
+	s.AwayRuns, s.HomeRuns = ab.AwayTeamRuns, ab.HomeTeamRuns
+
+	return m.fn(GameStep{
+		Kind:    PlateAppearanceStep,
+		AtBat:   ab,
+		Runners: runners,
+		Before:  before,
+		After:   *s,
+	})
+}
+
+// pitch applies a pitch to the count and returns its step.
+func (m *stateMachine) pitch(ab *AtBat, p *Pitch) GameStep {
+	s := &m.state
+	before := *s
+
+	switch p.Type {
+	case "B":
+		if !strings.HasPrefix(p.Des, "Hit By Pitch") {
+			s.Balls++
+		}
+	case "S":
+		if s.Strikes < 2 || !isFoul(p.Des) {
+			s.Strikes++
+		}
+	}
+
+	runners := runnersAt(ab, p.EventNum)
+	m.advance(runners)
+
+	return GameStep{
+		Kind:    PitchStep,
+		AtBat:   ab,
+		Pitch:   p,
+		Runners: runners,
+		Before:  before,
+		After:   *s,
+	}
+}
+
+// action applies an action and returns its step. ab is nil for actions
+// after the last plate appearance of a half inning.
+func (m *stateMachine) action(ab *AtBat, a *Action) GameStep {
+	s := &m.state
+	before := *s
+
+	if a.Event == "Pitching Substitution" && a.Player != 0 {
+		s.team(false).Pitcher = a.Player
+	}
+
+	var runners []Runner
+	if ab != nil {
+		runners = runnersAt(ab, a.EventNum)
+	}
+	m.advance(runners)
+	if a.Outs > s.Outs {
+		s.Outs = a.Outs
+	}
+
+	return GameStep{
+		Kind:    ActionStep,
+		AtBat:   ab,
+		Action:  a,
+		Runners: runners,
+		Before:  before,
+		After:   *s,
+	}
+}
+
+// advance moves runners between the bases and scores the runs of those who
+// reach home. Movements of the same runner are combined and every runner
+// moves at once, so the order of the movements does not matter. Runners are
+// removed from the base they started on whatever its occupant, which
+// accounts for pinch runners.
+func (m *stateMachine) advance(runners []Runner) {
+	type move struct {
+		id         int
+		start, end int
+		scored     bool
+	}
+
+	var moves []move
+	index := make(map[int]int)
+	for _, r := range runners {
+		if i, ok := index[r.ID]; ok {
+			moves[i].end = baseIndex(r.End)
+			moves[i].scored = moves[i].scored || r.Score == "T"
+			continue
+		}
+		index[r.ID] = len(moves)
+		moves = append(moves,
+			move{r.ID, baseIndex(r.Start), baseIndex(r.End), r.Score == "T"})
+	}
+
+	s := &m.state
+	for _, mv := range moves {
+		if mv.start >= 0 {
+			s.Bases[mv.start] = 0
+		}
+	}
+	for _, mv := range moves {
+		switch {
+		case mv.scored && s.TopInning:
+			s.AwayRuns++
+		case mv.scored:
+			s.HomeRuns++
+		case mv.end >= 0:
+			s.Bases[mv.end] = mv.id
+		}
+	}
+}
+
+// runnersAt returns the runner movements of an at-bat on the pitch or action
+// with the provided event number.
+func runnersAt(ab *AtBat, eventNum int) []Runner {
+	if eventNum == 0 || eventNum == ab.EventNum {
+		return nil
+	}
+
+	var runners []Runner
+	for _, r := range ab.Runners {
+		if r.EventNum == eventNum {
+			runners = append(runners, r)
+		}
+	}
+	return runners
+}
+
+// hasPitchingChange returns whether one of the actions is a pitching change.
+func hasPitchingChange(actions []*Action) bool {
+	for _, a := range actions {
+		if a.Event == "Pitching Substitution" {
+			return true
+		}
+	}
+	return false
+}
+
+// isFoul returns whether a pitch is a foul ball, which is not a strike with
+// two strikes. Foul tips and foul bunts are always strikes.
+func isFoul(des string) bool {
+	return strings.HasPrefix(des, "Foul") &&
+		!strings.HasPrefix(des, "Foul Tip") &&
+		!strings.HasPrefix(des, "Foul Bunt")
+}
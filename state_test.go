@@ -0,0 +1,163 @@
+package mlbgameday
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWalkGameStates(t *testing.T) {
+	abs := new(AtBats)
+	readMock(t, "./mock/inning_all.xml", abs)
+
+	steps := GameSteps(abs)
+	pas := make(map[int]GameStep)
+	for _, step := range steps {
+		if step.Kind == PlateAppearanceStep {
+			pas[step.AtBat.Number] = step
+		}
+	}
+	if len(pas) != 88 {
+		t.Fatalf("GameSteps returned %v plate appearances, want 88", len(pas))
+	}
+
+	first := pas[1]
+	want := GameState{
+		1, true, 0, 0, 0, Bases{}, 0, 0,
+		TeamState{0, 502481, 1}, TeamState{621244, 0, 0},
+	}
+	if !reflect.DeepEqual(first.Before, want) {
+		t.Errorf("GameSteps at-bat 1 started with %+v, want %+v",
+			first.Before, want)
+	}
+	want.Strikes, want.Bases = 1, Bases{502481, 0, 0}
+	if !reflect.DeepEqual(first.After, want) {
+		t.Errorf("GameSteps at-bat 1 ended with %+v, want %+v",
+			first.After, want)
+	}
+
+	var testCases = []struct {
+		Number int
+		Slot   int
+		Before Bases
+		After  Bases
+		Outs   int
+		Away   int
+		Home   int
+	}{
+		{3, 3, Bases{502481, 0, 0}, Bases{}, 3, 0, 0},
+		{18, 1, Bases{}, Bases{}, 1, 2, 1},
+		{40, 3, Bases{502481, 0, 0}, Bases{543333, 0, 502481}, 2, 2, 4},
+		{41, 4, Bases{543333, 0, 502481}, Bases{}, 2, 5, 4},
+		{62, 5, Bases{434778, 449181, 0}, Bases{521692, 434778, 0}, 1, 7, 4},
+		{88, 6, Bases{592696, 0, 0}, Bases{}, 3, 11, 5},
+	}
+	for _, tc := range testCases {
+		step := pas[tc.Number]
+		if slot := step.Before.Offense().Slot; slot != tc.Slot {
+			t.Errorf("GameSteps at-bat %v has lineup slot %v, want %v",
+				tc.Number, slot, tc.Slot)
+		}
+		if step.Before.Bases != tc.Before || step.After.Bases != tc.After {
+			t.Errorf("GameSteps at-bat %v moved runners from %v to %v, want %v to %v",
+				tc.Number, step.Before.Bases, step.After.Bases, tc.Before, tc.After)
+		}
+		if a := step.After; a.Outs != tc.Outs || a.AwayRuns != tc.Away || a.HomeRuns != tc.Home {
+			t.Errorf("GameSteps at-bat %v ended with %v outs, score %v-%v, want %v outs, score %v-%v",
+				tc.Number, a.Outs, a.AwayRuns, a.HomeRuns, tc.Outs, tc.Away, tc.Home)
+		}
+	}
+
+	last := steps[len(steps)-1].After
+	if last.Inning != 9 || last.TopInning || last.Outs != 3 {
+		t.Errorf("GameSteps ended in inning %v, top %v with %v outs, want bottom of 9th with 3 outs",
+			last.Inning, last.TopInning, last.Outs)
+	}
+}
+
+func TestWalkGameStatesActions(t *testing.T) {
+	abs := new(AtBats)
+	readMock(t, "./mock/inning_all.xml", abs)
+
+	var steal, change *GameStep
+	for _, step := range GameSteps(abs) {
+		step := step
+		if step.Kind != ActionStep {
+			continue
+		}
+		switch step.Action.EventNum {
+		case 319:
+			steal = &step
+		case 387:
+			change = &step
+		}
+	}
+
+	if steal == nil || change == nil {
+		t.Fatal("GameSteps did not walk the stolen base and pitching change")
+	}
+
+	if steal.AtBat.Number != 40 || steal.Before.Bases != (Bases{502481, 0, 0}) ||
+		steal.After.Bases != (Bases{0, 0, 502481}) || len(steal.Runners) != 1 {
+		t.Errorf("GameSteps stolen base in at-bat %v moved runners from %v to %v, want 1 runner from 1B to 3B in at-bat 40",
+			steal.AtBat.Number, steal.Before.Bases, steal.After.Bases)
+	}
+	if steal.Before.Balls != 3 || steal.Before.Strikes != 1 {
+		t.Errorf("GameSteps stolen base occurred on a %v-%v count, want 3-1",
+			steal.Before.Balls, steal.Before.Strikes)
+	}
+
+	if change.Before.Home.Pitcher != 621244 || change.After.Home.Pitcher != 592872 {
+		t.Errorf("GameSteps pitching change replaced %v with %v, want 621244 with 592872",
+			change.Before.Home.Pitcher, change.After.Home.Pitcher)
+	}
+}
+
+func TestWalkGameStatesCount(t *testing.T) {
+	ab := AtBat{
+		AtBatSummary: AtBatSummary{Number: 1, Batter: 1, Pitcher: 2},
+		Pitches: []Pitch{
+			{Des: "Ball", Type: "B"},
+			{Des: "Called Strike", Type: "S"},
+			{Des: "Foul", Type: "S"},
+			{Des: "Foul", Type: "S"},
+			{Des: "Hit By Pitch", Type: "B"},
+		},
+	}
+	abs := &AtBats{[]AtBatInning{{Number: 1, Top: []AtBat{ab}}}}
+
+	var counts [][2]int
+	for _, step := range GameSteps(abs) {
+		if step.Kind == PitchStep {
+			counts = append(counts, [2]int{step.After.Balls, step.After.Strikes})
+		}
+	}
+
+	want := [][2]int{{1, 0}, {1, 1}, {1, 2}, {1, 2}, {1, 2}}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("GameSteps counts are %v, want %v", counts, want)
+	}
+}
+
+func TestWalkGameStatesError(t *testing.T) {
+	abs := new(AtBats)
+	readMock(t, "./mock/inning_all.xml", abs)
+
+	calls := 0
+	err := WalkGameStates(abs, func(GameStep) error {
+		calls++
+		return errors.New("Stop")
+	})
+	testError(t, "WalkGameStates", "Stop", err)
+	if calls != 1 {
+		t.Errorf("WalkGameStates called fn %v times, want 1", calls)
+	}
+}
+
+func TestBases(t *testing.T) {
+	b := Bases{502481, 0, 449181}
+	if b.BaseState() != 5 || b.Runners() != 2 {
+		t.Errorf("Bases %v has state %v with %v runners, want 5 with 2",
+			b, b.BaseState(), b.Runners())
+	}
+}
@@ -0,0 +1,870 @@
+package mlbgameday
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnsupported is returned by services of the StatsAPI backend for
+// resources that the MLB Stats API does not provide.
+var ErrUnsupported = errors.New("Not supported by the Stats API backend")
+
+// StatsGamedayServiceOp communicates with the MLB Stats API to access the
+// games for a day.
+type StatsGamedayServiceOp struct {
+	// The client used to communicate with the MLB Stats API.
+	client *Client
+
+	// The date with which this service is associated.
+	date time.Time
+
+	// The sport with which this service is associated.
+	sport Sport
+}
+
+// NewStatsGamedayService returns a new StatsGamedayServiceOp for MLB games on
+// the provided date. Communication with the MLB Stats API occurs through the
+// provided Client. The provided date will be converted to ET to be
+// consistent with the Gameday API.
+func NewStatsGamedayService(client *Client, date time.Time) GamedayService {
+	return newStatsGamedayService(client, MLB, date)
+}
+
+// newStatsGamedayService returns a new StatsGamedayServiceOp for games of the
+// provided sport on the provided date.
+func newStatsGamedayService(client *Client, sport Sport, date time.Time) GamedayService {
+	return &StatsGamedayServiceOp{
+		client: client,
+		date:   DateOf(date).Time(),
+		sport:  sport,
+	}
+}
+
+// Scoreboard lists all the games scheduled on the provided date. The GID of
+// each game is its MLB Stats API game ID (gamePk).
+func (s *StatsGamedayServiceOp) Scoreboard() (*Scoreboard, error) {
+	sched, err := s.schedule("team,linescore")
+	if err != nil {
+		return nil, err
+	}
+
+	sb := new(Scoreboard)
+	for _, g := range sched {
+		sb.Games = append(sb.Games, g.game())
+	}
+
+	return sb, nil
+}
+
+// MasterScoreboard lists all the games scheduled on the provided date along
+// with their probable and decision pitchers and broadcasts. The MLB Stats API
+// schedule does not list home runs.
+func (s *StatsGamedayServiceOp) MasterScoreboard() (*MasterScoreboard, error) {
+	sched, err := s.schedule(
+		"team,linescore,probablePitcher,decisions,broadcasts(all)")
+	if err != nil {
+		return nil, err
+	}
+
+	sb := new(MasterScoreboard)
+	for _, g := range sched {
+		sb.Games = append(sb.Games, g.masterGame())
+	}
+
+	return sb, nil
+}
+
+// GamesInProgress lists all the games for the current day that are in
+// progress.
+func (s *StatsGamedayServiceOp) GamesInProgress() ([]Game, error) {
+	sb, err := s.Scoreboard()
+	if err != nil {
+		return nil, err
+	}
+
+	return gamesInProgress(sb), nil
+}
+
+// Game returns a new StatsGameServiceOp linked to the game referenced by the
+// provided MLB Stats API game ID (gamePk).
+func (s *StatsGamedayServiceOp) Game(gamePk string) (GameService, error) {
+	return NewStatsGameService(s.client, gamePk)
+}
+
+// GamesForTeam lists the games scheduled on the provided date for the team
+// referred to by the provided key, which may be any key accepted by
+// LookupFranchise.
+func (s *StatsGamedayServiceOp) GamesForTeam(team string) ([]Game, error) {
+	sb, err := s.Scoreboard()
+	if err != nil {
+		return nil, err
+	}
+
+	return sb.GamesForTeam(team), nil
+}
+
+// GameForTeam returns a GameService for each game scheduled on the provided
+// date for the team referred to by the provided key. It returns two services
+// when the team plays a doubleheader.
+func (s *StatsGamedayServiceOp) GameForTeam(team string) ([]GameService, error) {
+	return gameForTeam(s, team)
+}
+
+// ListGIDs lists the MLB Stats API game IDs (gamePks) of every game
+// scheduled on the provided date.
+func (s *StatsGamedayServiceOp) ListGIDs() ([]string, error) {
+	sched, err := s.schedule("")
+	if err != nil {
+		return nil, err
+	}
+
+	gids := []string{}
+	for _, g := range sched {
+		gids = append(gids, strconv.Itoa(g.GamePk))
+	}
+
+	return gids, nil
+}
+
+// schedule retrieves the games of this service's sport scheduled on the
+// provided date, hydrated with the provided comma-separated list of
+// hydrations.
+func (s *StatsGamedayServiceOp) schedule(hydrate string) ([]statsScheduleGame, error) {
+	path := fmt.Sprintf("api/v1/schedule?sportId=%d&date=%s",
+		s.sport.statsID(), s.date.Format("2006-01-02"))
+	if hydrate != "" {
+		path += "&hydrate=" + hydrate
+	}
+
+	sched := new(statsSchedule)
+	if err := s.client.getJSON(path, sched); err != nil {
+		return nil, err
+	}
+
+	var games []statsScheduleGame
+	for _, d := range sched.Dates {
+		games = append(games, d.Games...)
+	}
+
+	return games, nil
+}
+
+// StatsGameServiceOp communicates with the MLB Stats API to retrieve
+// information for a single game.
+type StatsGameServiceOp struct {
+	// The client used to communicate with the MLB Stats API.
+	client *Client
+
+	// The MLB Stats API game ID.
+	gamePk int
+}
+
+// NewStatsGameService returns a new StatsGameServiceOp that is linked to the
+// provided MLB Stats API game ID (gamePk). Communication with the MLB Stats
+// API occurs through the provided Client.
+func NewStatsGameService(client *Client, gamePk string) (GameService, error) {
+	pk, err := strconv.Atoi(gamePk)
+	if err != nil || pk <= 0 {
+		return nil, errors.New("Invalid game ID " + gamePk)
+	}
+
+	return &StatsGameServiceOp{client: client, gamePk: pk}, nil
+}
+
+// LineScore retrieves the line score for this game.
+func (s *StatsGameServiceOp) LineScore() (*LineScore, error) {
+	path := fmt.Sprintf("api/v1/schedule?gamePk=%d&hydrate=team,linescore",
+		s.gamePk)
+
+	sched := new(statsSchedule)
+	if err := s.client.getJSON(path, sched); err != nil {
+		return nil, err
+	}
+	if len(sched.Dates) == 0 || len(sched.Dates[0].Games) == 0 {
+		return nil, fmt.Errorf("Game %d not found", s.gamePk)
+	}
+
+	g := sched.Dates[0].Games[0]
+	ls := &LineScore{Game: g.game()}
+	for _, inn := range g.Linescore.Innings {
+		ls.Innings = append(ls.Innings, LineScoreInning{
+			Inning:   inn.Num,
+			HomeRuns: inn.Home.Runs,
+			AwayRuns: inn.Away.Runs,
+		})
+	}
+
+	return ls, nil
+}
+
+// Players returns the team rosters for this game.
+func (s *StatsGameServiceOp) Players() (*Players, error) {
+	box := new(statsBoxscore)
+	path := fmt.Sprintf("api/v1/game/%d/boxscore", s.gamePk)
+	if err := s.client.getJSON(path, box); err != nil {
+		return nil, err
+	}
+
+	p := &Players{
+		Teams: []Roster{box.Teams.Away.roster(), box.Teams.Home.roster()},
+	}
+	for _, o := range box.Officials {
+		p.Umpires = append(p.Umpires, Umpire{
+			ID:       o.Official.ID,
+			First:    firstName(o.Official.FullName),
+			Last:     lastName(o.Official.FullName),
+			Position: umpirePosition(o.OfficialType),
+			Name:     o.Official.FullName,
+		})
+	}
+
+	return p, nil
+}
+
+// Batter is not supported by the MLB Stats API backend.
+func (s *StatsGameServiceOp) Batter(id int) (*Batter, error) {
+	return nil, ErrUnsupported
+}
+
+// Pitcher is not supported by the MLB Stats API backend.
+func (s *StatsGameServiceOp) Pitcher(id int) (*Pitcher, error) {
+	return nil, ErrUnsupported
+}
+
+// AtBats returns all at-bats for this game, including any that are in
+// progress.
+func (s *StatsGameServiceOp) AtBats() (*AtBats, error) {
+	feed, err := s.feed()
+	if err != nil {
+		return nil, err
+	}
+
+	return feed.atBats(), nil
+}
+
+// CurrentAtBat returns the most current at-bat during a game in progress.
+// Calling CurrentAtBat for a game that has ended will return the game's final
+// at-bat.
+func (s *StatsGameServiceOp) CurrentAtBat() (*AtBat, error) {
+	g, err := s.AtBats()
+	if err != nil {
+		return nil, err
+	}
+
+	return currentAtBat(g)
+}
+
+// FilterAtBats returns all at-bats for which the evaluation in the provided
+// function returns true.
+func (s *StatsGameServiceOp) FilterAtBats(f func(*AtBat) bool) (*AtBats, error) {
+	all, err := s.AtBats()
+	if err != nil {
+		return nil, err
+	}
+
+	return filterAtBats(all, f), nil
+}
+
+// HitChart returns the locations of the balls put in play during this game.
+func (s *StatsGameServiceOp) HitChart() (*HitChart, error) {
+	feed, err := s.feed()
+	if err != nil {
+		return nil, err
+	}
+
+	return feed.hitChart(), nil
+}
+
+// Notifications returns the substitutions made by each team during this
+// game. The MLB Stats API does not provide general game notifications.
+func (s *StatsGameServiceOp) Notifications() (*Notifications, error) {
+	feed, err := s.feed()
+	if err != nil {
+		return nil, err
+	}
+
+	return feed.notifications(), nil
+}
+
+// ListResources is not supported by the MLB Stats API backend.
+func (s *StatsGameServiceOp) ListResources() ([]string, error) {
+	return nil, ErrUnsupported
+}
+
+// feed retrieves the live game feed for this game.
+func (s *StatsGameServiceOp) feed() (*statsFeed, error) {
+	feed := new(statsFeed)
+	path := fmt.Sprintf("api/v1.1/game/%d/feed/live", s.gamePk)
+	if err := s.client.getJSON(path, feed); err != nil {
+		return nil, err
+	}
+
+	return feed, nil
+}
+
+// getJSON retrieves the MLB Stats API resource at the requested path and
+// decodes it into v.
+func (c *Client) getJSON(path string, v interface{}) error {
+	data, err := c.get(path)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// game maps a scheduled game into a Game.
+func (g *statsScheduleGame) game() Game {
+	away, home := g.Teams.Away.Team, g.Teams.Home.Team
+	ls := g.Linescore
+
+	game := Game{
+		GID:            strconv.Itoa(g.GamePk),
+		AwayNameAbbrev: away.Abbreviation,
+		HomeNameAbbrev: home.Abbreviation,
+		AwayTeamCity:   away.LocationName,
+		HomeTeamCity:   home.LocationName,
+		AwayTeamName:   away.TeamName,
+		HomeTeamName:   home.TeamName,
+		Status:         gameStatus(g.Status),
+		Inning:         ls.CurrentInning,
+		Outs:           ls.Outs,
+		AwayTeamRuns:   ls.Teams.Away.Runs,
+		HomeTeamRuns:   ls.Teams.Home.Runs,
+		AwayHitsRuns:   ls.Teams.Away.Hits,
+		HomeHitsRuns:   ls.Teams.Home.Hits,
+		AwayTeamErrors: ls.Teams.Away.Errors,
+		HomeTeamErrors: ls.Teams.Home.Errors,
+		BaseState: baseState(ls.Offense.First != nil,
+			ls.Offense.Second != nil, ls.Offense.Third != nil),
+		GameNumber:   g.GameNumber,
+		DoubleHeader: g.DoubleHeader,
+		OriginalDate: statsDate(g.OfficialDate),
+		ResumeDate:   statsDate(g.ResumeDate),
+	}
+	if g.ResumedFrom != "" {
+		game.OriginalDate = statsDate(g.ResumedFrom)
+	}
+
+	if ls.CurrentInning > 0 {
+		game.TopInning = yesNo(ls.IsTopInning)
+	}
+
+	if t, err := time.Parse(time.RFC3339, g.GameDate); err == nil {
+		t = t.In(eastern)
+		game.TimeDate = t.Format("2006/01/02 3:04")
+		game.TimeZone = "ET"
+		game.AMPM = t.Format("PM")
+	}
+
+	return game
+}
+
+// masterGame maps a scheduled game, hydrated with its probable pitchers,
+// decisions and broadcasts, into a MasterGame.
+func (g *statsScheduleGame) masterGame() MasterGame {
+	ls := g.Linescore
+
+	mg := MasterGame{
+		Game: g.game(),
+		GameStatus: GameStatus{
+			Status:  gameStatus(g.Status),
+			Inning:  ls.CurrentInning,
+			Balls:   ls.Balls,
+			Strikes: ls.Strikes,
+			Outs:    ls.Outs,
+		},
+		LineScore: ScoreboardLineScore{
+			Runs:   ScoreboardTotal{ls.Teams.Away.Runs, ls.Teams.Home.Runs},
+			Hits:   ScoreboardTotal{ls.Teams.Away.Hits, ls.Teams.Home.Hits},
+			Errors: ScoreboardTotal{ls.Teams.Away.Errors, ls.Teams.Home.Errors},
+		},
+		WinningPitcher:      scoreboardPitcher(g.Decisions.Winner),
+		LosingPitcher:       scoreboardPitcher(g.Decisions.Loser),
+		SavePitcher:         scoreboardPitcher(g.Decisions.Save),
+		AwayProbablePitcher: scoreboardPitcher(g.Teams.Away.ProbablePitcher),
+		HomeProbablePitcher: scoreboardPitcher(g.Teams.Home.ProbablePitcher),
+	}
+	mg.GameStatus.TopInning = mg.TopInning
+
+	for _, inn := range ls.Innings {
+		mg.LineScore.Innings = append(mg.LineScore.Innings,
+			ScoreboardInning{inn.Away.Runs, inn.Home.Runs})
+	}
+
+	for _, b := range g.Broadcasts {
+		outlets := &mg.Broadcast.Home
+		if b.HomeAway == "away" {
+			outlets = &mg.Broadcast.Away
+		}
+		switch b.Type {
+		case "TV":
+			outlets.TV = joinOutlet(outlets.TV, b.Name)
+		case "AM", "FM":
+			outlets.Radio = joinOutlet(outlets.Radio, b.Name)
+		}
+	}
+
+	return mg
+}
+
+// roster maps a team in the box score into a Roster. Players are ordered by
+// ID.
+func (t *statsBoxscoreTeam) roster() Roster {
+	r := Roster{TeamID: t.Team.Abbreviation}
+
+	for _, p := range t.Players {
+		num, _ := strconv.Atoi(p.JerseyNumber)
+
+		// The batting order of a starter is his slot times 100; substitutes
+		// add 1 for each player who replaced him.
+		var order int
+		var position string
+		if n, _ := strconv.Atoi(p.BattingOrder); n > 0 && n%100 == 0 {
+			order = n / 100
+			if len(p.AllPositions) > 0 {
+				position = p.AllPositions[0].Abbreviation
+			}
+		}
+
+		r.Players = append(r.Players, Player{
+			ID:       p.Person.ID,
+			First:    firstName(p.Person.FullName),
+			Last:     lastName(p.Person.FullName),
+			Num:      num,
+			Position: p.Position.Abbreviation,
+			Status:   p.Status.Code,
+			Avg:      parseFloat32(p.SeasonStats.Batting.Avg),
+			HR:       p.SeasonStats.Batting.HomeRuns,
+			RBI:      p.SeasonStats.Batting.RBI,
+			Wins:     p.SeasonStats.Pitching.Wins,
+			Losses:   p.SeasonStats.Pitching.Losses,
+			ERA:      parseFloat32(p.SeasonStats.Pitching.ERA),
+
+			BatOrder:     order,
+			GamePosition: position,
+		})
+	}
+	sort.Slice(r.Players, func(i, j int) bool {
+		return r.Players[i].ID < r.Players[j].ID
+	})
+
+	for _, c := range t.Coaches {
+		num, _ := strconv.Atoi(c.JerseyNumber)
+		r.Coaches = append(r.Coaches, Coach{
+			ID:       c.Person.ID,
+			First:    firstName(c.Person.FullName),
+			Last:     lastName(c.Person.FullName),
+			Num:      num,
+			Position: strings.ToLower(c.Job),
+		})
+	}
+
+	return r
+}
+
+// atBats maps the plays in the live game feed into AtBats.
+func (f *statsFeed) atBats() *AtBats {
+	abs := new(AtBats)
+	eventNum := 0
+	for _, p := range f.LiveData.Plays.AllPlays {
+		for len(abs.Innings) < p.About.Inning {
+			abs.Innings = append(abs.Innings,
+				AtBatInning{Number: len(abs.Innings) + 1})
+		}
+		if p.About.Inning < 1 {
+			continue
+		}
+
+		ab, actions := p.atBat(&eventNum)
+		inn := &abs.Innings[p.About.Inning-1]
+		if p.About.HalfInning == "top" {
+			inn.Top = append(inn.Top, ab)
+			inn.TopActions = append(inn.TopActions, actions...)
+		} else {
+			inn.Bottom = append(inn.Bottom, ab)
+			inn.BottomActions = append(inn.BottomActions, actions...)
+		}
+	}
+
+	return abs
+}
+
+// hitChart maps the hit data of the balls put in play in the live game feed
+// into a HitChart.
+func (f *statsFeed) hitChart() *HitChart {
+	chart := new(HitChart)
+	for _, p := range f.LiveData.Plays.AllPlays {
+		team := "H"
+		if p.About.HalfInning == "top" {
+			team = "A"
+		}
+		for _, e := range p.PlayEvents {
+			if !e.Details.IsInPlay || e.HitData == nil {
+				continue
+			}
+			chart.Hits = append(chart.Hits, HitLocation{
+				Des:     p.Result.Event,
+				X:       e.HitData.Coordinates.CoordX,
+				Y:       e.HitData.Coordinates.CoordY,
+				Batter:  p.Matchup.Batter.ID,
+				Pitcher: p.Matchup.Pitcher.ID,
+				Type:    hitType(p.Result.Event),
+				Team:    team,
+				Inning:  p.About.Inning,
+			})
+		}
+	}
+
+	return chart
+}
+
+// hitType returns the Type of a HitLocation with the provided event.
+func hitType(event string) string {
+	switch {
+	case isHit(event):
+		return "H"
+	case strings.Contains(event, "Error"):
+		return "E"
+	}
+	return "O"
+}
+
+// notifications maps the substitutions in the live game feed into
+// Notifications.
+func (f *statsFeed) notifications() *Notifications {
+	away, home := f.GameData.Teams.Away, f.GameData.Teams.Home
+	n := &Notifications{
+		Teams: []Team{
+			{ID: away.ID, Code: away.TeamCode},
+			{ID: home.ID, Code: home.TeamCode},
+		},
+	}
+
+	for _, p := range f.LiveData.Plays.AllPlays {
+		top := p.About.HalfInning == "top"
+		for _, e := range p.PlayEvents {
+			category, offense := substitution(e)
+			if category == "" {
+				continue
+			}
+
+			notification := Notification{
+				Inning:  p.About.Inning,
+				Top:     yesNo(top),
+				AtBat:   p.About.AtBatIndex + 1,
+				Outs:    e.Count.Outs,
+				Players: []Player{{ID: e.Player.ID}},
+				Types:   []Type{{category}},
+			}
+			if e.ReplacedPlayer != nil {
+				notification.Players = append(notification.Players,
+					Player{ID: e.ReplacedPlayer.ID})
+			}
+
+			// The batting team makes offensive substitutions; the
+			// fielding team makes all others.
+			team := &n.Teams[1]
+			if top == offense {
+				team = &n.Teams[0]
+			}
+			team.Notifications = append(team.Notifications, notification)
+		}
+	}
+
+	return n
+}
+
+// atBat maps a play in the live game feed into an AtBat and the Actions
+// that occurred during it. The feed has no equivalent of Gameday event
+// numbers, so the play's events are numbered in order starting after
+// *eventNum, which is advanced past the play.
+func (p *statsPlay) atBat(eventNum *int) (AtBat, []Action) {
+	ab := AtBat{
+		AtBatSummary: AtBatSummary{
+			Number:       p.About.AtBatIndex + 1,
+			Batter:       p.Matchup.Batter.ID,
+			Pitcher:      p.Matchup.Pitcher.ID,
+			Balls:        p.Count.Balls,
+			Strikes:      p.Count.Strikes,
+			Outs:         p.Count.Outs,
+			Event:        p.Result.Event,
+			HomeTeamRuns: p.Result.HomeScore,
+			AwayTeamRuns: p.Result.AwayScore,
+		},
+		Stand:   p.Matchup.BatSide.Code,
+		PThrows: p.Matchup.PitchHand.Code,
+		Des:     p.Result.Description,
+	}
+
+	var actions []Action
+	actionNums := make(map[int]int)
+	for i, e := range p.PlayEvents {
+		*eventNum++
+		switch {
+		case e.IsPitch:
+			pitch := e.pitch()
+			pitch.EventNum = *eventNum
+			ab.Pitches = append(ab.Pitches, pitch)
+		case e.Type == "action":
+			action := e.action()
+			action.Pitch = len(ab.Pitches)
+			action.EventNum = *eventNum
+			actions = append(actions, action)
+			actionNums[i] = *eventNum
+		}
+	}
+	*eventNum++
+	ab.EventNum = *eventNum
+
+	for _, r := range p.Runners {
+		runner := r.runner()
+		runner.EventNum = ab.EventNum
+		if n, ok := actionNums[r.Details.PlayIndex]; ok {
+			runner.EventNum = n
+		}
+		ab.Runners = append(ab.Runners, runner)
+	}
+
+	return ab, actions
+}
+
+// actionEvents maps the event types of substitutions in the live game feed,
+// which have no event, into the events used by the Gameday API.
+var actionEvents = map[string]string{
+	"pitching_substitution":  "Pitching Substitution",
+	"offensive_substitution": "Offensive Sub",
+	"defensive_substitution": "Defensive Sub",
+	"defensive_switch":       "Defensive Switch",
+}
+
+// action maps an action event in the live game feed into an Action.
+func (e *statsPlayEvent) action() Action {
+	a := Action{
+		Balls:   e.Count.Balls,
+		Strikes: e.Count.Strikes,
+		Outs:    e.Count.Outs,
+		Des:     e.Details.Description,
+		Event:   e.Details.Event,
+		Player:  e.Player.ID,
+		TFSZulu: zulu(e.EndTime),
+	}
+	if a.Event == "" {
+		a.Event = actionEvents[e.Details.EventType]
+	}
+
+	return a
+}
+
+// runner maps a runner's movement in the live game feed into a Runner.
+func (r *statsRunner) runner() Runner {
+	m := r.Movement
+	runner := Runner{
+		ID:     r.Details.Runner.ID,
+		Start:  m.Start,
+		End:    m.End,
+		Event:  r.Details.Event,
+		RBI:    trueOrEmpty(r.Details.RBI),
+		Earned: trueOrEmpty(r.Details.Earned),
+	}
+	if m.End == "score" {
+		runner.End = ""
+		runner.Score = "T"
+	}
+	if m.IsOut {
+		runner.End = ""
+	}
+
+	return runner
+}
+
+// pitch maps a pitch event in the live game feed into a Pitch.
+func (e *statsPlayEvent) pitch() Pitch {
+	d, c, b := e.PitchData, e.PitchData.Coordinates, e.PitchData.Breaks
+
+	p := Pitch{
+		Des:            e.Details.Description,
+		TFSZulu:        zulu(e.EndTime),
+		X:              c.X,
+		Y:              c.Y,
+		StartSpeed:     d.StartSpeed,
+		EndSpeed:       d.EndSpeed,
+		SZTop:          d.StrikeZoneTop,
+		SZBot:          d.StrikeZoneBottom,
+		PfxX:           c.PfxX,
+		PfxZ:           c.PfxZ,
+		PX:             c.PX,
+		PZ:             c.PZ,
+		X0:             c.X0,
+		Y0:             c.Y0,
+		Z0:             c.Z0,
+		VX0:            c.VX0,
+		VY0:            c.VY0,
+		VZ0:            c.VZ0,
+		AX:             c.AX,
+		AY:             c.AY,
+		AZ:             c.AZ,
+		BreakY:         b.BreakY,
+		BreakAngle:     b.BreakAngle,
+		BreakLength:    b.BreakLength,
+		PitchType:      e.Details.Type.Code,
+		TypeConfidence: d.TypeConfidence,
+		Zone:           d.Zone,
+		Nasty:          d.NastyFactor,
+		SpinDir:        b.SpinDirection,
+		SpinRate:       b.SpinRate,
+	}
+
+	switch {
+	case e.Details.IsInPlay:
+		p.Type = "X"
+	case e.Details.IsStrike:
+		p.Type = "S"
+	case e.Details.IsBall:
+		p.Type = "B"
+	}
+
+	return p
+}
+
+// zulu reformats a time in the live game feed, which includes fractional
+// seconds, in the format used by the Gameday API. Times that cannot be
+// parsed are returned unchanged.
+func zulu(s string) string {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC().Format("2006-01-02T15:04:05Z")
+	}
+	return s
+}
+
+// substitution returns the Gameday notification category of a substitution
+// event and whether the batting team made it. The category is empty for
+// events that are not substitutions.
+func substitution(e statsPlayEvent) (string, bool) {
+	if e.Type != "action" {
+		return "", false
+	}
+
+	switch e.Details.EventType {
+	case "pitching_substitution":
+		return "pitching change", false
+	case "offensive_substitution":
+		if strings.Contains(e.Details.Description, "Pinch-runner") {
+			return "pinch runner", true
+		}
+		return "pinch hitter", true
+	case "defensive_substitution":
+		return "defensive substitution", false
+	case "defensive_switch":
+		return "defensive switch", false
+	}
+
+	return "", false
+}
+
+// gameStatus maps the status of a game in the MLB Stats API into the status
+// used by the Gameday API.
+func gameStatus(s statsStatus) string {
+	if s.DetailedState == "Scheduled" {
+		return "Preview"
+	}
+	return s.DetailedState
+}
+
+// baseState returns the Gameday base state for the provided occupied bases.
+// See Game.BaseState.
+func baseState(first, second, third bool) int {
+	switch {
+	case first && second && third:
+		return 7
+	case second && third:
+		return 6
+	case first && third:
+		return 5
+	case first && second:
+		return 4
+	case third:
+		return 3
+	case second:
+		return 2
+	case first:
+		return 1
+	}
+	return 0
+}
+
+// scoreboardPitcher maps a pitcher reference into a ScoreboardPitcher.
+func scoreboardPitcher(p *statsPerson) ScoreboardPitcher {
+	if p == nil {
+		return ScoreboardPitcher{}
+	}
+
+	return ScoreboardPitcher{
+		ID:    p.ID,
+		First: firstName(p.FullName),
+		Last:  lastName(p.FullName),
+	}
+}
+
+// umpirePosition maps an official type into the position of an Umpire.
+func umpirePosition(officialType string) string {
+	return strings.ToLower(strings.TrimSuffix(
+		strings.TrimSuffix(officialType, " Plate"), " Base"))
+}
+
+// joinOutlet appends name to a comma-separated list of broadcast outlets.
+func joinOutlet(outlets, name string) string {
+	if outlets == "" {
+		return name
+	}
+	return outlets + ", " + name
+}
+
+// firstName returns the first word of a full name.
+func firstName(fullName string) string {
+	return strings.SplitN(fullName, " ", 2)[0]
+}
+
+// lastName returns all but the first word of a full name.
+func lastName(fullName string) string {
+	toks := strings.SplitN(fullName, " ", 2)
+	if len(toks) < 2 {
+		return ""
+	}
+	return toks[1]
+}
+
+// statsDate converts a YYYY-MM-DD date into the YYYY/MM/DD form used by the
+// Gameday API.
+func statsDate(date string) string {
+	return strings.Replace(date, "-", "/", -1)
+}
+
+// yesNo returns "Y" if b is true and "N" otherwise.
+func yesNo(b bool) string {
+	if b {
+		return "Y"
+	}
+	return "N"
+}
+
+// trueOrEmpty returns the Gameday API representation of a flag that is
+// either T or absent.
+func trueOrEmpty(b bool) string {
+	if b {
+		return "T"
+	}
+	return ""
+}
+
+// parseFloat32 parses a decimal stat such as ".254" and returns zero if it
+// cannot be parsed.
+func parseFloat32(s string) float32 {
+	f, _ := strconv.ParseFloat(s, 32)
+	return float32(f)
+}
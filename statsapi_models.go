@@ -0,0 +1,280 @@
+package mlbgameday
+
+// The types in this file mirror the JSON documents served by the MLB Stats
+// API. They are decoded as-is and then mapped into the package's Gameday
+// types.
+
+// statsSchedule represents the response of the schedule endpoint.
+type statsSchedule struct {
+	Dates []struct {
+		Games []statsScheduleGame `json:"games"`
+	} `json:"dates"`
+}
+
+// statsScheduleGame represents a game on the schedule, hydrated with its
+// teams and line score.
+type statsScheduleGame struct {
+	GamePk       int            `json:"gamePk"`
+	GameDate     string         `json:"gameDate"`
+	OfficialDate string         `json:"officialDate"`
+	ResumeDate   string         `json:"resumeDate"`
+	ResumedFrom  string         `json:"resumedFromDate"`
+	DoubleHeader string         `json:"doubleHeader"`
+	GameNumber   int            `json:"gameNumber"`
+	Status       statsStatus    `json:"status"`
+	Linescore    statsLinescore `json:"linescore"`
+	Teams        struct {
+		Away statsScheduleTeam `json:"away"`
+		Home statsScheduleTeam `json:"home"`
+	} `json:"teams"`
+	Decisions struct {
+		Winner *statsPerson `json:"winner"`
+		Loser  *statsPerson `json:"loser"`
+		Save   *statsPerson `json:"save"`
+	} `json:"decisions"`
+	Broadcasts []struct {
+		Type     string `json:"type"`
+		Name     string `json:"name"`
+		HomeAway string `json:"homeAway"`
+	} `json:"broadcasts"`
+}
+
+// statsScheduleTeam represents one side of a game on the schedule.
+type statsScheduleTeam struct {
+	Team            statsTeam    `json:"team"`
+	Score           int          `json:"score"`
+	ProbablePitcher *statsPerson `json:"probablePitcher"`
+}
+
+// statsStatus represents the status of a game.
+type statsStatus struct {
+	AbstractGameState string `json:"abstractGameState"`
+	DetailedState     string `json:"detailedState"`
+}
+
+// statsTeam represents a team.
+type statsTeam struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	TeamCode     string `json:"teamCode"`
+	FileCode     string `json:"fileCode"`
+	Abbreviation string `json:"abbreviation"`
+	TeamName     string `json:"teamName"`
+	LocationName string `json:"locationName"`
+}
+
+// statsLinescore represents the line score of a game.
+type statsLinescore struct {
+	CurrentInning int                    `json:"currentInning"`
+	IsTopInning   bool                   `json:"isTopInning"`
+	Balls         int                    `json:"balls"`
+	Strikes       int                    `json:"strikes"`
+	Outs          int                    `json:"outs"`
+	Innings       []statsLinescoreInning `json:"innings"`
+	Teams         struct {
+		Away statsLinescoreTeam `json:"away"`
+		Home statsLinescoreTeam `json:"home"`
+	} `json:"teams"`
+	Offense struct {
+		First  *statsPerson `json:"first"`
+		Second *statsPerson `json:"second"`
+		Third  *statsPerson `json:"third"`
+	} `json:"offense"`
+}
+
+// statsLinescoreInning represents a single inning of the line score.
+type statsLinescoreInning struct {
+	Num  int                `json:"num"`
+	Away statsLinescoreTeam `json:"away"`
+	Home statsLinescoreTeam `json:"home"`
+}
+
+// statsLinescoreTeam represents a team's runs, hits and errors.
+type statsLinescoreTeam struct {
+	Runs   int `json:"runs"`
+	Hits   int `json:"hits"`
+	Errors int `json:"errors"`
+}
+
+// statsPerson represents a reference to a player, coach or umpire.
+type statsPerson struct {
+	ID       int    `json:"id"`
+	FullName string `json:"fullName"`
+}
+
+// statsBoxscore represents the response of the boxscore endpoint.
+type statsBoxscore struct {
+	Teams struct {
+		Away statsBoxscoreTeam `json:"away"`
+		Home statsBoxscoreTeam `json:"home"`
+	} `json:"teams"`
+	Officials []struct {
+		Official     statsPerson `json:"official"`
+		OfficialType string      `json:"officialType"`
+	} `json:"officials"`
+}
+
+// statsBoxscoreTeam represents a team's roster in the box score.
+type statsBoxscoreTeam struct {
+	Team    statsTeam                      `json:"team"`
+	Players map[string]statsBoxscorePlayer `json:"players"`
+	Coaches []struct {
+		Person       statsPerson `json:"person"`
+		JerseyNumber string      `json:"jerseyNumber"`
+		Job          string      `json:"job"`
+	} `json:"coaches"`
+}
+
+// statsBoxscorePlayer represents a player in the box score.
+type statsBoxscorePlayer struct {
+	Person       statsPerson `json:"person"`
+	JerseyNumber string      `json:"jerseyNumber"`
+	Position     struct {
+		Abbreviation string `json:"abbreviation"`
+	} `json:"position"`
+	Status struct {
+		Code string `json:"code"`
+	} `json:"status"`
+	BattingOrder string `json:"battingOrder"`
+	AllPositions []struct {
+		Abbreviation string `json:"abbreviation"`
+	} `json:"allPositions"`
+	SeasonStats struct {
+		Batting struct {
+			Avg      string `json:"avg"`
+			HomeRuns int    `json:"homeRuns"`
+			RBI      int    `json:"rbi"`
+		} `json:"batting"`
+		Pitching struct {
+			Wins   int    `json:"wins"`
+			Losses int    `json:"losses"`
+			ERA    string `json:"era"`
+		} `json:"pitching"`
+	} `json:"seasonStats"`
+}
+
+// statsFeed represents the response of the live game feed endpoint.
+type statsFeed struct {
+	GameData struct {
+		Teams struct {
+			Away statsTeam `json:"away"`
+			Home statsTeam `json:"home"`
+		} `json:"teams"`
+	} `json:"gameData"`
+	LiveData struct {
+		Plays struct {
+			AllPlays []statsPlay `json:"allPlays"`
+		} `json:"plays"`
+	} `json:"liveData"`
+}
+
+// statsPlay represents a single plate appearance in the live game feed.
+type statsPlay struct {
+	Result struct {
+		Event       string `json:"event"`
+		Description string `json:"description"`
+		AwayScore   int    `json:"awayScore"`
+		HomeScore   int    `json:"homeScore"`
+	} `json:"result"`
+	About struct {
+		AtBatIndex int    `json:"atBatIndex"`
+		HalfInning string `json:"halfInning"`
+		Inning     int    `json:"inning"`
+	} `json:"about"`
+	Count   statsCount `json:"count"`
+	Matchup struct {
+		Batter  statsPerson `json:"batter"`
+		Pitcher statsPerson `json:"pitcher"`
+		BatSide struct {
+			Code string `json:"code"`
+		} `json:"batSide"`
+		PitchHand struct {
+			Code string `json:"code"`
+		} `json:"pitchHand"`
+	} `json:"matchup"`
+	PlayEvents []statsPlayEvent `json:"playEvents"`
+	Runners    []statsRunner    `json:"runners"`
+}
+
+// statsRunner represents the movement of a runner during a plate appearance.
+type statsRunner struct {
+	Movement struct {
+		Start string `json:"start"`
+		End   string `json:"end"`
+		IsOut bool   `json:"isOut"`
+	} `json:"movement"`
+	Details struct {
+		Event     string      `json:"event"`
+		Runner    statsPerson `json:"runner"`
+		RBI       bool        `json:"rbi"`
+		Earned    bool        `json:"earned"`
+		PlayIndex int         `json:"playIndex"`
+	} `json:"details"`
+}
+
+// statsCount represents the balls, strikes and outs at a point in a game.
+type statsCount struct {
+	Balls   int `json:"balls"`
+	Strikes int `json:"strikes"`
+	Outs    int `json:"outs"`
+}
+
+// statsPlayEvent represents a pitch or an action during a plate appearance.
+type statsPlayEvent struct {
+	IsPitch bool        `json:"isPitch"`
+	Type    string      `json:"type"`
+	EndTime string      `json:"endTime"`
+	Count   statsCount  `json:"count"`
+	Player  statsPerson `json:"player"`
+	Details struct {
+		Description string `json:"description"`
+		Event       string `json:"event"`
+		EventType   string `json:"eventType"`
+		IsInPlay    bool   `json:"isInPlay"`
+		IsStrike    bool   `json:"isStrike"`
+		IsBall      bool   `json:"isBall"`
+		Type        struct {
+			Code string `json:"code"`
+		} `json:"type"`
+	} `json:"details"`
+	ReplacedPlayer *statsPerson `json:"replacedPlayer"`
+	PitchData      struct {
+		StartSpeed       float32 `json:"startSpeed"`
+		EndSpeed         float32 `json:"endSpeed"`
+		StrikeZoneTop    float32 `json:"strikeZoneTop"`
+		StrikeZoneBottom float32 `json:"strikeZoneBottom"`
+		Zone             float32 `json:"zone"`
+		TypeConfidence   float32 `json:"typeConfidence"`
+		NastyFactor      float32 `json:"nastyFactor"`
+		Coordinates      struct {
+			X    float32 `json:"x"`
+			Y    float32 `json:"y"`
+			PfxX float32 `json:"pfxX"`
+			PfxZ float32 `json:"pfxZ"`
+			PX   float32 `json:"pX"`
+			PZ   float32 `json:"pZ"`
+			X0   float32 `json:"x0"`
+			Y0   float32 `json:"y0"`
+			Z0   float32 `json:"z0"`
+			VX0  float32 `json:"vX0"`
+			VY0  float32 `json:"vY0"`
+			VZ0  float32 `json:"vZ0"`
+			AX   float32 `json:"aX"`
+			AY   float32 `json:"aY"`
+			AZ   float32 `json:"aZ"`
+		} `json:"coordinates"`
+		Breaks struct {
+			BreakAngle    float32 `json:"breakAngle"`
+			BreakLength   float32 `json:"breakLength"`
+			BreakY        float32 `json:"breakY"`
+			SpinRate      float32 `json:"spinRate"`
+			SpinDirection float32 `json:"spinDirection"`
+		} `json:"breaks"`
+	} `json:"pitchData"`
+	HitData *struct {
+		Coordinates struct {
+			CoordX float32 `json:"coordX"`
+			CoordY float32 `json:"coordY"`
+		} `json:"coordinates"`
+	} `json:"hitData"`
+}
@@ -0,0 +1,459 @@
+package mlbgameday
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func setupStatsAPI(t *testing.T) GamedayService {
+	mux.HandleFunc("/api/v1/schedule", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch q := r.URL.Query(); {
+		case q.Get("date") == "2016-09-05" && q.Get("sportId") == "1":
+			serveFile(t, w, "./mock/statsapi/schedule.json")
+		case q.Get("gamePk") == "448916":
+			serveFile(t, w, "./mock/statsapi/schedule_game.json")
+		default:
+			http.Error(w, "Not Found", 404)
+		}
+	})
+	mux.HandleFunc("/api/v1/game/448916/boxscore", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		serveFile(t, w, "./mock/statsapi/boxscore.json")
+	})
+	mux.HandleFunc("/api/v1.1/game/448916/feed/live", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		serveFile(t, w, "./mock/statsapi/feed_live.json")
+	})
+
+	client.Backend = StatsAPI
+	return setupGameday()
+}
+
+func serveFile(t *testing.T, w http.ResponseWriter, file string) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal("Could not read data file")
+	}
+	fmt.Fprint(w, string(data))
+}
+
+func TestNewStatsAPIClient(t *testing.T) {
+	t.Parallel()
+	c := NewStatsAPIClient(nil)
+	got := c.BaseURL.String()
+	want := "https://statsapi.mlb.com"
+	if got != want {
+		t.Errorf("client.BaseURL.String() == %q, want %q", got, want)
+	}
+
+	l, _ := time.LoadLocation("America/New_York")
+	svc := c.Gameday(time.Date(2016, 9, 5, 0, 0, 0, 0, l))
+	if _, ok := svc.(*StatsGamedayServiceOp); !ok {
+		t.Errorf("client.Gameday returned %T, want *StatsGamedayServiceOp", svc)
+	}
+}
+
+func TestStatsScoreboard(t *testing.T) {
+	setup()
+	defer teardown()
+
+	gameday := setupStatsAPI(t)
+
+	got, err := gameday.Scoreboard()
+	if err != nil {
+		t.Fatalf("Gameday.Scoreboard returned error: %v", err)
+	}
+
+	want := &Scoreboard{
+		[]Game{
+			{
+				"448923",
+				"2016/09/05 1:05", "ET", "PM",
+				"TOR", "NYY", "Toronto", "NY Yankees", "Blue Jays", "Yankees",
+				"Y", "In Progress", 1, 0,
+				0, 0, 1, 0, 0, 0, 2,
+				1, "N", "2016/09/05", "",
+				"", "", "", "", 0,
+			},
+			{
+				"448918",
+				"2016/09/05 1:10", "ET", "PM",
+				"NYM", "CIN", "NY Mets", "Cincinnati", "Mets", "Reds",
+				"", "Preview", 0, 0,
+				0, 0, 0, 0, 0, 0, 0,
+				1, "N", "2016/09/05", "",
+				"", "", "", "", 0,
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Gameday.Scoreboard returned %v, want %v", got, want)
+	}
+}
+
+func TestStatsGamesInProgress(t *testing.T) {
+	setup()
+	defer teardown()
+
+	gameday := setupStatsAPI(t)
+
+	got, err := gameday.GamesInProgress()
+	if err != nil {
+		t.Fatalf("Gameday.GamesInProgress returned error: %v", err)
+	}
+
+	if len(got) != 1 || got[0].GID != "448923" {
+		t.Errorf("Gameday.GamesInProgress returned %v, want game 448923", got)
+	}
+}
+
+func TestStatsMasterScoreboard(t *testing.T) {
+	setup()
+	defer teardown()
+
+	gameday := setupStatsAPI(t)
+
+	got, err := gameday.MasterScoreboard()
+	if err != nil {
+		t.Fatalf("Gameday.MasterScoreboard returned error: %v", err)
+	}
+
+	if len(got.Games) != 2 {
+		t.Fatalf("Gameday.MasterScoreboard returned %v games, want 2",
+			len(got.Games))
+	}
+
+	live := got.Games[0]
+	if live.GameStatus.Strikes != 1 || live.LineScore.Hits.Away != 1 {
+		t.Errorf("MasterGame is %+v, want 1 strike and 1 away hit", live)
+	}
+	wantBroadcast := Broadcast{
+		BroadcastOutlets{"SNLV", ""},
+		BroadcastOutlets{"YES", "WFAN 660"},
+	}
+	if live.Broadcast != wantBroadcast {
+		t.Errorf("MasterGame.Broadcast is %v, want %v",
+			live.Broadcast, wantBroadcast)
+	}
+
+	preview := got.Games[1]
+	wantAway := ScoreboardPitcher{ID: 592789, First: "Noah", Last: "Syndergaard"}
+	if preview.AwayProbablePitcher != wantAway {
+		t.Errorf("MasterGame.AwayProbablePitcher is %v, want %v",
+			preview.AwayProbablePitcher, wantAway)
+	}
+	wantHome := ScoreboardPitcher{ID: 608566, First: "Cody", Last: "Reed"}
+	if preview.HomeProbablePitcher != wantHome {
+		t.Errorf("MasterGame.HomeProbablePitcher is %v, want %v",
+			preview.HomeProbablePitcher, wantHome)
+	}
+}
+
+func TestStatsListGIDs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	gameday := setupStatsAPI(t)
+
+	got, err := gameday.ListGIDs()
+	if err != nil {
+		t.Fatalf("Gameday.ListGIDs returned error: %v", err)
+	}
+
+	want := []string{"448923", "448918"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Gameday.ListGIDs returned %v, want %v", got, want)
+	}
+}
+
+func TestStatsGameErrorInvalidID(t *testing.T) {
+	setup()
+	defer teardown()
+
+	gameday := setupStatsAPI(t)
+
+	gid := "2016_09_05_kcamlb_minmlb_1"
+	got, err := gameday.Game(gid)
+	if got != nil {
+		t.Errorf("Gameday.Game returned %v, want nil", got)
+	}
+
+	testError(t, "Gameday.Game", "Invalid game ID "+gid, err)
+}
+
+func TestStatsLineScore(t *testing.T) {
+	setup()
+	defer teardown()
+
+	game, err := setupStatsAPI(t).Game("448916")
+	if err != nil {
+		t.Fatalf("Gameday.Game returned error: %v", err)
+	}
+
+	got, err := game.LineScore()
+	if err != nil {
+		t.Fatalf("Game.LineScore returned error: %v", err)
+	}
+
+	want := &LineScore{
+		Game: Game{
+			"448916", "2016/09/05 2:10", "ET", "PM",
+			"KC", "MIN", "Kansas City", "Minnesota", "Royals", "Twins",
+			"Y", "In Progress", 7, 0,
+			5, 4, 10, 9, 0, 1, 0,
+			1, "N", "2016/09/05", "",
+			"", "", "", "", 0,
+		},
+		Innings: []LineScoreInning{
+			{1, 0, 1},
+			{2, 2, 0},
+			{3, 0, 2},
+			{4, 0, 1},
+			{5, 2, 0},
+			{6, 0, 0},
+			{7, 0, 1},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Game.LineScore returned %v, want %v", got, want)
+	}
+}
+
+func TestStatsLineScoreErrorHTTP404(t *testing.T) {
+	setup()
+	defer teardown()
+
+	game, err := setupStatsAPI(t).Game("1")
+	if err != nil {
+		t.Fatalf("Gameday.Game returned error: %v", err)
+	}
+
+	got, err := game.LineScore()
+	if got != nil {
+		t.Errorf("Game.LineScore returned %v, want nil", got)
+	}
+
+	testError(t, "Game.LineScore", "HTTP 404", err)
+}
+
+func TestStatsPlayers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	game, err := setupStatsAPI(t).Game("448916")
+	if err != nil {
+		t.Fatalf("Gameday.Game returned error: %v", err)
+	}
+
+	got, err := game.Players()
+	if err != nil {
+		t.Fatalf("Game.Players returned error: %v", err)
+	}
+
+	want := &Players{
+		[]Roster{
+			{
+				"KC",
+				[]Player{
+					{
+						521692, "Salvador", "Perez", 13, "", "", "C", "A",
+						0.254, 20, 58, 0, 0, 0, 5, "C",
+					},
+					{
+						572044, "Brooks", "Pounders", 62, "", "", "P", "A",
+						0.000, 0, 0, 1, 1, 10.29, 0, "",
+					},
+				},
+				[]Coach{{124681, "Ned", "Yost", 3, "manager"}},
+			},
+			{
+				"MIN",
+				[]Player{
+					{
+						542953, "Buddy", "Boshers", 62, "", "", "P", "A",
+						0.000, 0, 0, 2, 0, 5.33, 0, "",
+					},
+					{
+						621439, "Byron", "Buxton", 25, "", "", "CF", "A",
+						0.221, 4, 25, 0, 0, 0, 9, "CF",
+					},
+				},
+				[]Coach{{119236, "Paul", "Molitor", 4, "manager"}},
+			},
+		},
+		[]Umpire{{427019, "Ted", "Barrett", "home", "Ted Barrett"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Game.Players returned %v, want %v", got, want)
+	}
+}
+
+func TestStatsAtBats(t *testing.T) {
+	setup()
+	defer teardown()
+
+	game, err := setupStatsAPI(t).Game("448916")
+	if err != nil {
+		t.Fatalf("Gameday.Game returned error: %v", err)
+	}
+
+	got, err := game.AtBats()
+	if err != nil {
+		t.Fatalf("Game.AtBats returned error: %v", err)
+	}
+
+	if len(got.Innings) != 7 {
+		t.Fatalf("Game.AtBats returned %v innings, want 7", len(got.Innings))
+	}
+
+	want := AtBat{
+		AtBatSummary{1, 502481, 621244, 0, 1, 0, "Single", 0, 0},
+		[]Pitch{
+			{
+				"Called Strike",
+				"S", "2016-09-05T18:10:36Z", 151.27, 180.81, 92.5, 85.4, 3.47,
+				1.62, -0.98, 9.59, -0.899, 2.147, -1.76, 50.0, 5.492, 2.649,
+				-135.539, -6.307, -1.831, 28.902, -14.218, 23.8, 4.7, 3.4,
+				"FF", 0.914, 13, 72, 185.821, 1931.941, 1,
+			},
+		},
+		2, "L", "R",
+		"Jarrod Dyson singles on a line drive to right fielder Logan Schafer.",
+		[]Runner{{502481, "", "1B", "Single", 2, "", "", ""}},
+	}
+	if top := got.Innings[0].Top; len(top) != 1 || !reflect.DeepEqual(top[0], want) {
+		t.Errorf("Game.AtBats top of 1st is %v, want [%v]", top, want)
+	}
+
+	cur, err := game.CurrentAtBat()
+	if err != nil {
+		t.Fatalf("Game.CurrentAtBat returned error: %v", err)
+	}
+
+	wantCur := AtBatSummary{69, 435559, 572044, 1, 3, 3, "Strikeout", 4, 7}
+	if cur.AtBatSummary != wantCur || len(cur.Pitches) != 1 ||
+		cur.Pitches[0].Type != "B" {
+		t.Errorf("Game.CurrentAtBat returned %v, want %v with 1 ball",
+			cur, wantCur)
+	}
+
+	wantActions := []Action{
+		{
+			0, 0, 2, "Pitching Change: Brooks Pounders replaces Brian Flynn.",
+			"Pitching Substitution", 572044, 0, 3, "", 0, 0,
+		},
+		{
+			0, 0, 2,
+			"Offensive Substitution: Pinch-hitter Kurt Suzuki replaces Juan Centeno.",
+			"Offensive Sub", 435559, 0, 4, "", 0, 0,
+		},
+	}
+	if actions := got.Innings[6].BottomActions; !reflect.DeepEqual(actions, wantActions) {
+		t.Errorf("Game.AtBats bottom of 7th actions are %v, want %v",
+			actions, wantActions)
+	}
+}
+
+func TestStatsNotifications(t *testing.T) {
+	setup()
+	defer teardown()
+
+	game, err := setupStatsAPI(t).Game("448916")
+	if err != nil {
+		t.Fatalf("Gameday.Game returned error: %v", err)
+	}
+
+	got, err := game.Notifications()
+	if err != nil {
+		t.Fatalf("Game.Notifications returned error: %v", err)
+	}
+
+	want := &Notifications{
+		Teams: []Team{
+			{
+				118, "kca", []Notification{
+					{
+						7, "N", 69, 2,
+						[]Player{{ID: 572044}, {ID: 543169}},
+						[]Type{{"pitching change"}},
+					},
+				},
+			},
+			{
+				142, "min", []Notification{
+					{
+						7, "N", 69, 2,
+						[]Player{{ID: 435559}, {ID: 518542}},
+						[]Type{{"pinch hitter"}},
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Game.Notifications returned %v, want %v", got, want)
+	}
+}
+
+func TestStatsHitChart(t *testing.T) {
+	setup()
+	defer teardown()
+
+	game, err := setupStatsAPI(t).Game("448916")
+	if err != nil {
+		t.Fatalf("Gameday.Game returned error: %v", err)
+	}
+
+	// The mock feed has no balls in play.
+	got, err := game.HitChart()
+	if err != nil {
+		t.Fatalf("Game.HitChart returned error: %v", err)
+	}
+	if len(got.Hits) != 0 {
+		t.Errorf("Game.HitChart returned %v, want no hits", got)
+	}
+
+	feed := new(statsFeed)
+	err = json.Unmarshal([]byte(`{"liveData": {"plays": {"allPlays": [{
+		"result": {"event": "Field Error"},
+		"about": {"halfInning": "bottom", "inning": 3},
+		"matchup": {"batter": {"id": 1}, "pitcher": {"id": 2}},
+		"playEvents": [
+			{"isPitch": true, "details": {"isInPlay": false}},
+			{"isPitch": true, "details": {"isInPlay": true},
+			 "hitData": {"coordinates": {"coordX": 98.5, "coordY": 160.25}}}
+		]
+	}]}}}`), feed)
+	if err != nil {
+		t.Fatalf("Could not decode feed: %v", err)
+	}
+	want := []HitLocation{{"Field Error", 98.5, 160.25, 1, 2, "E", "H", 3}}
+	if got := feed.hitChart().Hits; !reflect.DeepEqual(got, want) {
+		t.Errorf("statsFeed.hitChart returned %+v, want %+v", got, want)
+	}
+
+	for event, want := range map[string]string{"Double": "H", "Groundout": "O", "Field Error": "E"} {
+		if got := hitType(event); got != want {
+			t.Errorf("hitType(%q) returned %v, want %v", event, got, want)
+		}
+	}
+}
+
+func TestStatsUnsupported(t *testing.T) {
+	game, err := NewStatsGameService(NewStatsAPIClient(nil), "448916")
+	if err != nil {
+		t.Fatalf("NewStatsGameService returned error: %v", err)
+	}
+
+	_, err = game.Batter(521692)
+	testError(t, "Game.Batter", ErrUnsupported.Error(), err)
+
+	_, err = game.ListResources()
+	testError(t, "Game.ListResources", ErrUnsupported.Error(), err)
+}
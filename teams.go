@@ -0,0 +1,145 @@
+package mlbgameday
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Franchise represents an MLB team and the codes by which the MLB Gameday
+// API and MLB Stats API refer to it.
+type Franchise struct {
+	// ID is the MLB team ID, e.g. 118.
+	ID int
+
+	// Abbrev is the team abbreviation used on scoreboards, e.g. "KC".
+	Abbrev string
+
+	// Code is the Gameday team code used in GIDs, e.g. "kca".
+	Code string
+
+	// FileCode is the team code used in file names, e.g. "kc".
+	FileCode string
+
+	City string
+	Name string
+
+	// Aliases are other abbreviations that refer to the team, e.g. "AZ".
+	Aliases []string
+}
+
+// Franchises lists every MLB team.
+var Franchises = []Franchise{
+	{108, "LAA", "ana", "laa", "LA Angels", "Angels", []string{"ANA"}},
+	{109, "ARI", "ari", "ari", "Arizona", "D-backs", []string{"AZ"}},
+	{110, "BAL", "bal", "bal", "Baltimore", "Orioles", nil},
+	{111, "BOS", "bos", "bos", "Boston", "Red Sox", nil},
+	{112, "CHC", "chn", "chc", "Chi Cubs", "Cubs", nil},
+	{113, "CIN", "cin", "cin", "Cincinnati", "Reds", nil},
+	{114, "CLE", "cle", "cle", "Cleveland", "Indians", nil},
+	{115, "COL", "col", "col", "Colorado", "Rockies", nil},
+	{116, "DET", "det", "det", "Detroit", "Tigers", nil},
+	{117, "HOU", "hou", "hou", "Houston", "Astros", nil},
+	{118, "KC", "kca", "kc", "Kansas City", "Royals", []string{"KCR"}},
+	{119, "LAD", "lan", "la", "LA Dodgers", "Dodgers", nil},
+	{120, "WSH", "was", "was", "Washington", "Nationals", []string{"WSN"}},
+	{121, "NYM", "nyn", "nym", "NY Mets", "Mets", nil},
+	{133, "OAK", "oak", "oak", "Oakland", "Athletics", nil},
+	{134, "PIT", "pit", "pit", "Pittsburgh", "Pirates", nil},
+	{135, "SD", "sdn", "sd", "San Diego", "Padres", []string{"SDP"}},
+	{136, "SEA", "sea", "sea", "Seattle", "Mariners", nil},
+	{137, "SF", "sfn", "sf", "San Francisco", "Giants", []string{"SFG"}},
+	{138, "STL", "sln", "stl", "St. Louis", "Cardinals", nil},
+	{139, "TB", "tba", "tb", "Tampa Bay", "Rays", []string{"TBR"}},
+	{140, "TEX", "tex", "tex", "Texas", "Rangers", nil},
+	{141, "TOR", "tor", "tor", "Toronto", "Blue Jays", nil},
+	{142, "MIN", "min", "min", "Minnesota", "Twins", nil},
+	{143, "PHI", "phi", "phi", "Philadelphia", "Phillies", nil},
+	{144, "ATL", "atl", "atl", "Atlanta", "Braves", nil},
+	{145, "CWS", "cha", "cws", "Chi White Sox", "White Sox", []string{"CHW"}},
+	{146, "MIA", "mia", "mia", "Miami", "Marlins", nil},
+	{147, "NYY", "nya", "nyy", "NY Yankees", "Yankees", nil},
+	{158, "MIL", "mil", "mil", "Milwaukee", "Brewers", nil},
+}
+
+// LookupFranchise returns the MLB team referred to by the provided key,
+// which may be a team ID ("118"), abbreviation ("KC"), Gameday team code
+// ("kca"), file code ("kc"), GID team code ("kcamlb") or alias. Keys are
+// not case sensitive.
+func LookupFranchise(key string) (Franchise, bool) {
+	key = strings.ToLower(strings.TrimSpace(key))
+	if id, err := strconv.Atoi(key); err == nil {
+		for _, f := range Franchises {
+			if f.ID == id {
+				return f, true
+			}
+		}
+		return Franchise{}, false
+	}
+	key = strings.TrimSuffix(key, string(MLB))
+
+	for _, f := range Franchises {
+		if key == strings.ToLower(f.Abbrev) || key == f.Code ||
+			key == f.FileCode {
+			return f, true
+		}
+		for _, alias := range f.Aliases {
+			if key == strings.ToLower(alias) {
+				return f, true
+			}
+		}
+	}
+
+	return Franchise{}, false
+}
+
+// GamesForTeam returns the games on the scoreboard played by the team
+// referred to by the provided key, which may be any key accepted by
+// LookupFranchise. A team plays more than one game on days with a
+// doubleheader.
+func (sb *Scoreboard) GamesForTeam(team string) []Game {
+	var games []Game
+	for _, g := range sb.Games {
+		if g.HasTeam(team) {
+			games = append(games, g)
+		}
+	}
+
+	return games
+}
+
+// HasTeam returns whether the team referred to by the provided key, which
+// may be any key accepted by LookupFranchise, is playing in the game. Teams
+// are matched by abbreviation or by their code in the GID, so Gameday games
+// known only by GID are matched too.
+func (g *Game) HasTeam(team string) bool {
+	codes := []string{g.AwayNameAbbrev, g.HomeNameAbbrev}
+	if toks := strings.Split(g.GID, "_"); len(toks) == 6 {
+		codes = append(codes, toks[3], toks[4])
+	}
+
+	f, isFranchise := LookupFranchise(team)
+	team = strings.ToLower(team)
+	for _, code := range codes {
+		if code == "" {
+			continue
+		}
+		if isFranchise {
+			if gf, ok := LookupFranchise(code); ok && gf.ID == f.ID {
+				return true
+			}
+		} else if team == strings.ToLower(code) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GamesForTeam returns the games played on this day by the team referred
+// to by the provided key. See Scoreboard.GamesForTeam.
+func (d *Day) GamesForTeam(team string) []Game {
+	if d.Scoreboard == nil {
+		return nil
+	}
+	return d.Scoreboard.GamesForTeam(team)
+}
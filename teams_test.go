@@ -0,0 +1,71 @@
+package mlbgameday
+
+import "testing"
+
+func TestLookupFranchise(t *testing.T) {
+	var testCases = []struct {
+		Key string
+		ID  int
+		OK  bool
+	}{
+		{"KC", 118, true},
+		{"kca", 118, true},
+		{"kc", 118, true},
+		{"118", 118, true},
+		{"kcamlb", 118, true},
+		{"AZ", 109, true},
+		{"nya", 147, true},
+		{"NYM", 121, true},
+		{"omaaaa", 0, false},
+		{"999", 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Key, func(t *testing.T) {
+			f, ok := LookupFranchise(tc.Key)
+			if f.ID != tc.ID || ok != tc.OK {
+				t.Errorf("LookupFranchise(%q) == %v, %v, want ID %v, %v",
+					tc.Key, f, ok, tc.ID, tc.OK)
+			}
+		})
+	}
+}
+
+func TestGameHasTeam(t *testing.T) {
+	mlb := Game{
+		GID:            "2016_09_05_kcamlb_minmlb_1",
+		AwayNameAbbrev: "KC",
+		HomeNameAbbrev: "MIN",
+	}
+	aaa := Game{
+		GID:            "2016_08_20_omaaaa_iowaaa_2",
+		AwayNameAbbrev: "OMA",
+		HomeNameAbbrev: "IOW",
+	}
+
+	gid := Game{GID: "2016_09_05_kcamlb_minmlb_1"}
+
+	var testCases = []struct {
+		Game Game
+		Team string
+		Want bool
+	}{
+		{mlb, "kca", true},
+		{mlb, "142", true},
+		{mlb, "min", true},
+		{mlb, "NYY", false},
+		{aaa, "oma", true},
+		{aaa, "iowaaa", true},
+		{aaa, "KC", false},
+		{gid, "KC", true},
+		{gid, "minmlb", true},
+		{gid, "NYY", false},
+	}
+
+	for _, tc := range testCases {
+		if got := tc.Game.HasTeam(tc.Team); got != tc.Want {
+			t.Errorf("Game(%v).HasTeam(%q) == %v, want %v",
+				tc.Game.GID, tc.Team, got, tc.Want)
+		}
+	}
+}
@@ -0,0 +1,307 @@
+// Package umpire evaluates the balls and strikes called by the home plate
+// umpire of a game.
+//
+// Every called pitch is judged against a mlbgameday.Zone, and the missed
+// calls are broken down by count, by the leverage of the plate appearance
+// and by the team they favored.
+package umpire
+
+import (
+	"sort"
+
+	"github.com/ericdreeves/mlbgameday"
+	"github.com/ericdreeves/mlbgameday/winexp"
+)
+
+// Leverage levels of a plate appearance, as conventionally bucketed by its
+// leverage index.
+const (
+	LowLeverage    = iota // Below 0.85
+	MediumLeverage        // From 0.85 to 2
+	HighLeverage          // Above 2
+)
+
+// LeverageLevel returns the leverage level of a leverage index.
+func LeverageLevel(li float64) int {
+	switch {
+	case li < 0.85:
+		return LowLeverage
+	case li <= 2:
+		return MediumLeverage
+	}
+	return HighLeverage
+}
+
+// Call represents a ball or strike called by the umpire.
+type Call struct {
+	AtBat *mlbgameday.AtBat
+	Pitch *mlbgameday.Pitch
+
+	// TopInning reports whether the away team was batting. Balls and Strikes
+	// are the count before the pitch.
+	TopInning bool
+	Balls     int
+	Strikes   int
+
+	// Leverage is the leverage index of the plate appearance.
+	Leverage float64
+
+	// Strike is the umpire's call, and InZone whether the pitch crossed the
+	// zone. Distance is the distance of the ball from the edge of the zone,
+	// in feet, negative inside.
+	Strike   bool
+	InZone   bool
+	Distance float64
+
+	// Consistent reports whether the call agrees with the umpire's own zone.
+	// See Report.
+	Consistent bool
+}
+
+// Correct returns whether the call agrees with the zone.
+func (c *Call) Correct() bool {
+	return c.Strike == c.InZone
+}
+
+// TeamFavor represents the missed calls that helped and hurt a team: strikes
+// called on balls thrown by its pitchers, and balls called on strikes to its
+// batters, helped it.
+type TeamFavor struct {
+	For     int
+	Against int
+}
+
+// Net returns the missed calls that helped the team less those that hurt it.
+func (f TeamFavor) Net() int {
+	return f.For - f.Against
+}
+
+// Report represents the evaluation of the calls of a game's home plate
+// umpire.
+type Report struct {
+	Umpire mlbgameday.Umpire
+
+	// Calls holds every called pitch with a location, in order.
+	Calls []Call
+
+	// Correct is the number of calls that agree with the zone, and
+	// Consistent the number that agree with the umpire's own zone: the
+	// convex hull of the locations of his called strikes, with the height
+	// of each pitch scaled to its batter's strike zone. Every called strike
+	// is consistent, as is every ball outside the hull.
+	Correct    int
+	Consistent int
+
+	// CalledByCount and MissedByCount hold the calls, and the missed calls,
+	// indexed by the balls and strikes before the pitch.
+	CalledByCount [4][3]int
+	MissedByCount [4][3]int
+
+	// CalledByLeverage and MissedByLeverage hold the calls, and the missed
+	// calls, indexed by leverage level.
+	CalledByLeverage [3]int
+	MissedByLeverage [3]int
+
+	Away TeamFavor
+	Home TeamFavor
+}
+
+// Accuracy returns the fraction of calls that agree with the zone.
+func (r *Report) Accuracy() float64 {
+	if len(r.Calls) == 0 {
+		return 0
+	}
+	return float64(r.Correct) / float64(len(r.Calls))
+}
+
+// Consistency returns the fraction of calls that agree with the umpire's own
+// zone.
+func (r *Report) Consistency() float64 {
+	if len(r.Calls) == 0 {
+		return 0
+	}
+	return float64(r.Consistent) / float64(len(r.Calls))
+}
+
+// Evaluate returns the report of the home plate umpire of a game, judging
+// his calls against the zone. The leverage of each plate appearance is
+// computed with model, or with the model of the game alone if model is nil.
+func Evaluate(game mlbgameday.GameService, zone mlbgameday.Zone, model *winexp.Model) (*Report, error) {
+	players, err := game.Players()
+	if err != nil {
+		return nil, err
+	}
+	abs, err := game.AtBats()
+	if err != nil {
+		return nil, err
+	}
+
+	r := Build(abs, zone, model)
+	for _, u := range players.Umpires {
+		if u.Position == "home" {
+			r.Umpire = u
+		}
+	}
+	return r, nil
+}
+
+// Build returns the report of the calls in the at-bats of a game, without
+// the umpire. See Evaluate.
+func Build(abs *mlbgameday.AtBats, zone mlbgameday.Zone, model *winexp.Model) *Report {
+	if model == nil {
+		model = winexp.Build(abs)
+	}
+
+	r := new(Report)
+	var leverage float64
+	var current *mlbgameday.AtBat
+	mlbgameday.WalkGameStates(abs, func(step mlbgameday.GameStep) error {
+		if step.Kind != mlbgameday.PitchStep {
+			return nil
+		}
+		if step.AtBat != current {
+			current = step.AtBat
+			leverage = model.Leverage(step.Before)
+		}
+
+		p := step.Pitch
+		if !p.IsCalled() || !p.HasLocation() {
+			return nil
+		}
+		r.Calls = append(r.Calls, Call{
+			AtBat:     step.AtBat,
+			Pitch:     p,
+			TopInning: step.Before.TopInning,
+			Balls:     step.Before.Balls,
+			Strikes:   step.Before.Strikes,
+			Leverage:  leverage,
+			Strike:    p.Type == "S",
+			InZone:    zone.Contains(p),
+			Distance:  zone.Distance(p),
+		})
+		return nil
+	})
+
+	r.tally()
+	return r
+}
+
+// tally counts the correct, consistent and missed calls.
+func (r *Report) tally() {
+	var strikes []point
+	for _, c := range r.Calls {
+		if c.Strike {
+			strikes = append(strikes, pointOf(c.Pitch))
+		}
+	}
+	hull := convexHull(strikes)
+
+	for i := range r.Calls {
+		c := &r.Calls[i]
+		c.Consistent = c.Strike || !hull.contains(pointOf(c.Pitch))
+		if c.Consistent {
+			r.Consistent++
+		}
+
+		level := LeverageLevel(c.Leverage)
+		r.CalledByLeverage[level]++
+		counted := c.Balls < 4 && c.Strikes < 3
+		if counted {
+			r.CalledByCount[c.Balls][c.Strikes]++
+		}
+		if c.Correct() {
+			r.Correct++
+			continue
+		}
+
+		r.MissedByLeverage[level]++
+		if counted {
+			r.MissedByCount[c.Balls][c.Strikes]++
+		}
+
+		// A missed strike helps the fielding team and a missed ball the
+		// batting team.
+		batting, fielding := &r.Home, &r.Away
+		if c.TopInning {
+			batting, fielding = &r.Away, &r.Home
+		}
+		if c.Strike {
+			fielding.For++
+			batting.Against++
+		} else {
+			batting.For++
+			fielding.Against++
+		}
+	}
+}
+
+// point represents the location of a pitch, with its height scaled so that
+// the batter's strike zone spans 0 to 1.
+type point struct {
+	x, z float64
+}
+
+// pointOf returns the scaled location of a pitch.
+func pointOf(p *mlbgameday.Pitch) point {
+	return point{
+		float64(p.PX),
+		float64(p.PZ-p.SZBot) / float64(p.SZTop-p.SZBot),
+	}
+}
+
+// cross returns the cross product of the vectors from o to a and o to b,
+// which is positive if o, a and b turn counterclockwise.
+func cross(o, a, b point) float64 {
+	return (a.x-o.x)*(b.z-o.z) - (a.z-o.z)*(b.x-o.x)
+}
+
+// polygon represents a convex polygon with its vertices in counterclockwise
+// order.
+type polygon []point
+
+// convexHull returns the convex hull of a set of points, using Andrew's
+// monotone chain algorithm.
+func convexHull(points []point) polygon {
+	ps := append([]point(nil), points...)
+	sort.Slice(ps, func(i, j int) bool {
+		if ps[i].x != ps[j].x {
+			return ps[i].x < ps[j].x
+		}
+		return ps[i].z < ps[j].z
+	})
+	if len(ps) < 3 {
+		return ps
+	}
+
+	hull := make(polygon, 0, 2*len(ps))
+	for _, p := range ps {
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	lower := len(hull) + 1
+	for i := len(ps) - 2; i >= 0; i-- {
+		p := ps[i]
+		for len(hull) >= lower && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+
+	return hull[:len(hull)-1]
+}
+
+// contains returns whether a point is inside the polygon or on its edge. A
+// polygon of fewer than three vertices contains nothing.
+func (poly polygon) contains(p point) bool {
+	if len(poly) < 3 {
+		return false
+	}
+	for i := range poly {
+		if cross(poly[i], poly[(i+1)%len(poly)], p) < 0 {
+			return false
+		}
+	}
+	return true
+}
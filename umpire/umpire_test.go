@@ -0,0 +1,106 @@
+package umpire
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/ericdreeves/mlbgameday"
+	"github.com/ericdreeves/mlbgameday/internal/testutil"
+	"github.com/ericdreeves/mlbgameday/winexp"
+)
+
+func TestEvaluate(t *testing.T) {
+	r, err := Evaluate(testutil.NewGame(t), mlbgameday.Zone{}, nil)
+	if err != nil {
+		t.Fatalf("Evaluate returned error: %v", err)
+	}
+
+	if r.Umpire.ID != 427019 {
+		t.Errorf("Evaluate returned umpire %+v, want 427019", r.Umpire)
+	}
+	if len(r.Calls) != 170 || r.Correct != 149 {
+		t.Errorf("Evaluate returned %v correct of %v calls, want 149 of 170",
+			r.Correct, len(r.Calls))
+	}
+
+	// The first pitch of the game, a called strike less than an inch off the zone.
+	c := r.Calls[0]
+	if c.Pitch.EventNum != 3 || !c.Strike || c.InZone || c.Correct() ||
+		c.Distance < 0.06 || c.Distance > 0.08 {
+		t.Errorf("Evaluate returned first call %+v, want a missed strike 0.06 to 0.08 feet off the zone",
+			c)
+	}
+
+	missed := 0
+	for _, row := range r.MissedByCount {
+		for _, n := range row {
+			missed += n
+		}
+	}
+	if missed != 21 {
+		t.Errorf("Evaluate returned %v missed calls by count, want 21", missed)
+	}
+
+	// Without a model, leverage is computed with the model of the game.
+	model := winexp.Build(testutil.AtBats(t))
+	if li := model.Leverage(mlbgameday.GameState{Inning: 1, TopInning: true}); math.Abs(c.Leverage-li) > 1e-9 {
+		t.Errorf("Evaluate returned first call leverage %v, want %v", c.Leverage, li)
+	}
+	if r.CalledByLeverage != [3]int{81, 66, 23} || r.MissedByLeverage != [3]int{11, 8, 2} {
+		t.Errorf("Evaluate returned %v missed of %v calls by leverage, want [11 8 2] of [81 66 23]",
+			r.MissedByLeverage, r.CalledByLeverage)
+	}
+
+	if r.Away.For != r.Home.Against || r.Home.For != r.Away.Against ||
+		r.Away.For+r.Home.For != 21 {
+		t.Errorf("Evaluate returned favor %+v and %+v, want 21 missed calls between them",
+			r.Away, r.Home)
+	}
+
+	if r.Consistent < r.Correct-5 || r.Consistent > len(r.Calls) ||
+		r.Consistency() != float64(r.Consistent)/170 {
+		t.Errorf("Evaluate returned %v consistent calls, want near %v", r.Consistent, r.Correct)
+	}
+
+	_, err = Evaluate(&testutil.Game{Err: errors.New("Not found")}, mlbgameday.Zone{}, nil)
+	if err == nil || err.Error() != "Not found" {
+		t.Errorf("Evaluate returned error %v, want Not found", err)
+	}
+}
+
+func TestConvexHull(t *testing.T) {
+	square := convexHull([]point{{0, 0}, {1, 0}, {0.5, 0.5}, {1, 1}, {0, 1}})
+	if len(square) != 4 {
+		t.Fatalf("convexHull returned %v, want 4 vertices", square)
+	}
+
+	var testCases = []struct {
+		Point point
+		Want  bool
+	}{
+		{point{0.5, 0.5}, true},
+		{point{1, 0.5}, true},
+		{point{1.1, 0.5}, false},
+		{point{0.5, -0.1}, false},
+	}
+	for _, tc := range testCases {
+		if got := square.contains(tc.Point); got != tc.Want {
+			t.Errorf("polygon.contains(%v) returned %v, want %v", tc.Point, got, tc.Want)
+		}
+	}
+
+	if convexHull([]point{{0, 0}, {1, 1}}).contains(point{0.5, 0.5}) {
+		t.Errorf("polygon.contains returned true for a polygon of 2 vertices")
+	}
+}
+
+func TestLeverageLevel(t *testing.T) {
+	for li, want := range map[float64]int{
+		0.5: LowLeverage, 0.85: MediumLeverage, 2: MediumLeverage, 2.5: HighLeverage,
+	} {
+		if got := LeverageLevel(li); got != want {
+			t.Errorf("LeverageLevel(%v) returned %v, want %v", li, got, want)
+		}
+	}
+}
@@ -0,0 +1,383 @@
+package mlbgameday
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventType identifies the kind of change reported by an Event.
+type EventType int
+
+// Types of events emitted by a Watcher.
+const (
+	NewPitch       EventType = iota // A pitch was thrown
+	AtBatComplete                   // An at-bat ended
+	RunScored                       // One or more runs scored on an at-bat
+	InningChange                    // A new half inning started
+	PitchingChange                  // A new pitcher entered the game
+	StatusChange                    // The game's status changed
+	GameFinal                       // The game ended
+	GameStarted                     // Play began
+	ScoreChange                     // A team's score changed
+	LeadChange                      // A team took the lead
+	GameDelayed                     // Play was delayed
+)
+
+var eventTypeNames = []string{
+	"NewPitch",
+	"AtBatComplete",
+	"RunScored",
+	"InningChange",
+	"PitchingChange",
+	"StatusChange",
+	"GameFinal",
+	"GameStarted",
+	"ScoreChange",
+	"LeadChange",
+	"GameDelayed",
+}
+
+// String returns the name of the event type, e.g. "NewPitch".
+func (t EventType) String() string {
+	if t < 0 || int(t) >= len(eventTypeNames) {
+		return "Unknown"
+	}
+	return eventTypeNames[t]
+}
+
+// Event represents a change in a game observed by a Watcher or
+// ScoreboardWatcher.
+type Event struct {
+	Type EventType
+
+	// GID identifies the game. It is the ID of the game's line score.
+	GID string
+
+	// Inning and TopInning identify the half inning in which the event
+	// occurred.
+	Inning    int
+	TopInning string
+
+	// AtBat is the at-bat during which a NewPitch, AtBatComplete, RunScored
+	// or PitchingChange event occurred.
+	AtBat *AtBat
+
+	// Pitch is the pitch thrown for a NewPitch event.
+	Pitch *Pitch
+
+	// Runs is the number of runs scored for a RunScored or ScoreChange event.
+	Runs int
+
+	// Status is the game's status for StatusChange, GameFinal, GameStarted
+	// and GameDelayed events.
+	Status string
+
+	// Game is the state of the game when the event was observed: its
+	// scoreboard entry for events emitted by a ScoreboardWatcher, and the
+	// game of its line score for events emitted by a Watcher.
+	Game *Game
+}
+
+// WatchOptions configures the polling intervals of a Watcher.
+type WatchOptions struct {
+	// InPlay is the polling interval while an inning is in progress.
+	// Defaults to 10 seconds.
+	InPlay time.Duration
+
+	// BetweenInnings is the polling interval between half innings.
+	// Defaults to 30 seconds.
+	BetweenInnings time.Duration
+
+	// Idle is the polling interval before the game starts and during
+	// delays. Defaults to 2 minutes.
+	Idle time.Duration
+
+	// Replay emits events for everything that happened before the first
+	// poll. By default the first poll only establishes the state of the game.
+	Replay bool
+}
+
+// Watcher polls a game and reports changes to it as events.
+type Watcher struct {
+	game GameService
+	opts WatchOptions
+
+	mu  sync.Mutex
+	err error
+
+	// State of the game as of the last poll.
+	polled    bool
+	status    string
+	inning    int
+	topInning string
+	pitches   map[int]int
+	completed map[int]bool
+	pitchers  map[string]int
+	runs      int
+}
+
+// NewWatcher returns a new Watcher for the provided game. opts may be nil.
+func NewWatcher(game GameService, opts *WatchOptions) *Watcher {
+	return &Watcher{
+		game:      game,
+		opts:      opts.withDefaults(),
+		pitches:   make(map[int]int),
+		completed: make(map[int]bool),
+		pitchers:  make(map[string]int),
+	}
+}
+
+// Watch polls the game until it is over or ctx is done and returns a channel
+// of the events observed, in the order in which they occurred. Events are
+// reported once even though they appear in every subsequent poll. The
+// channel is closed after the game's final event or once ctx is done. A
+// Watcher should only be watched once.
+func (w *Watcher) Watch(ctx context.Context) <-chan Event {
+	return watch(ctx, w.poll)
+}
+
+// Err returns the error of the most recent poll, if it failed. Failed polls
+// are retried at the idle interval.
+func (w *Watcher) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+// poll retrieves the game once and returns the events since the previous
+// poll, the interval until the next poll and whether the game is over.
+func (w *Watcher) poll() ([]Event, time.Duration, bool) {
+	ls, err := w.game.LineScore()
+	if err != nil {
+		w.setErr(err)
+		return nil, w.opts.Idle, false
+	}
+
+	var abs *AtBats
+	if hasStarted(ls.Status) {
+		if abs, err = w.game.AtBats(); err != nil {
+			w.setErr(err)
+			return nil, w.opts.Idle, false
+		}
+	}
+	w.setErr(nil)
+
+	emit := w.polled || w.opts.Replay
+	w.polled = true
+
+	var events []Event
+	add := func(e Event) {
+		if emit {
+			g := ls.Game
+			e.Game = &g
+			events = append(events, e)
+		}
+	}
+
+	if ls.Status != w.status {
+		add(Event{
+			Type: StatusChange, GID: ls.GID, Inning: ls.Inning,
+			TopInning: ls.TopInning, Status: ls.Status,
+		})
+		w.status = ls.Status
+	}
+
+	if abs != nil {
+		w.diffAtBats(ls.GID, abs, add)
+	}
+
+	if ls.Inning > 0 {
+		w.enterHalfInning(ls.GID, ls.Inning, ls.TopInning, add)
+	}
+
+	if isOver(ls.Status) {
+		if isFinal(ls.Status) {
+			g := ls.Game
+			events = append(events, Event{
+				Type: GameFinal, GID: ls.GID, Inning: ls.Inning,
+				TopInning: ls.TopInning, Status: ls.Status, Game: &g,
+			})
+		}
+		return events, 0, true
+	}
+
+	return events, w.opts.interval(&ls.Game), false
+}
+
+// diffAtBats reports the pitches, at-bats, runs and pitching changes in abs
+// that were not in previous polls.
+func (w *Watcher) diffAtBats(gid string, abs *AtBats, add func(Event)) {
+	for _, inning := range abs.Innings {
+		halves := []struct {
+			top string
+			abs []AtBat
+		}{{"Y", inning.Top}, {"N", inning.Bottom}}
+
+		for _, half := range halves {
+			for i := range half.abs {
+				ab := half.abs[i]
+				base := Event{
+					GID: gid, Inning: inning.Number, TopInning: half.top,
+					AtBat: &ab,
+				}
+
+				if _, seen := w.pitches[ab.Number]; !seen {
+					w.enterHalfInning(gid, inning.Number, half.top, add)
+					if p, ok := w.pitchers[half.top]; ok && p != ab.Pitcher {
+						e := base
+						e.Type = PitchingChange
+						add(e)
+					}
+					w.pitchers[half.top] = ab.Pitcher
+				}
+
+				for j := w.pitches[ab.Number]; j < len(ab.Pitches); j++ {
+					e := base
+					e.Type = NewPitch
+					e.Pitch = &ab.Pitches[j]
+					add(e)
+				}
+				w.pitches[ab.Number] = len(ab.Pitches)
+
+				if ab.Event == "" || w.completed[ab.Number] {
+					continue
+				}
+				w.completed[ab.Number] = true
+
+				e := base
+				e.Type = AtBatComplete
+				add(e)
+
+				if runs := ab.HomeTeamRuns + ab.AwayTeamRuns; runs > w.runs {
+					e.Type = RunScored
+					e.Runs = runs - w.runs
+					add(e)
+					w.runs = runs
+				}
+			}
+		}
+	}
+}
+
+// enterHalfInning reports an InningChange if the provided half inning is
+// later than the current half inning.
+func (w *Watcher) enterHalfInning(gid string, inning int, top string, add func(Event)) {
+	if halfInning(inning, top) <= halfInning(w.inning, w.topInning) {
+		return
+	}
+
+	add(Event{Type: InningChange, GID: gid, Inning: inning, TopInning: top})
+	w.inning, w.topInning = inning, top
+}
+
+// halfInning returns the ordinal of a half inning: 2 for the top of the 1st,
+// 3 for the bottom of the 1st and so on.
+func halfInning(inning int, top string) int {
+	if top == "N" {
+		return inning*2 + 1
+	}
+	return inning * 2
+}
+
+// withDefaults returns a copy of opts in which unset intervals are set to
+// their defaults. opts may be nil.
+func (opts *WatchOptions) withDefaults() WatchOptions {
+	var o WatchOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.InPlay <= 0 {
+		o.InPlay = 10 * time.Second
+	}
+	if o.BetweenInnings <= 0 {
+		o.BetweenInnings = 30 * time.Second
+	}
+	if o.Idle <= 0 {
+		o.Idle = 2 * time.Minute
+	}
+
+	return o
+}
+
+// interval returns the time until the next poll of a game in the provided
+// state.
+func (opts *WatchOptions) interval(g *Game) time.Duration {
+	switch {
+	case g.Status != "In Progress":
+		return opts.Idle
+	case g.Outs >= 3:
+		return opts.BetweenInnings
+	default:
+		return opts.InPlay
+	}
+}
+
+// watch calls poll until it reports that watching is over or ctx is done,
+// waiting the interval returned by each call before the next, and returns a
+// channel of the events returned by poll.
+func watch(ctx context.Context, poll func() ([]Event, time.Duration, bool)) <-chan Event {
+	out := make(chan Event)
+
+	go func() {
+		defer close(out)
+
+		for {
+			events, interval, over := poll()
+			for _, e := range events {
+				select {
+				case out <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if over {
+				return
+			}
+
+			t := time.NewTimer(interval)
+			select {
+			case <-t.C:
+			case <-ctx.Done():
+				t.Stop()
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+func (w *Watcher) setErr(err error) {
+	w.mu.Lock()
+	w.err = err
+	w.mu.Unlock()
+}
+
+// hasStarted returns whether a game with the provided status has started.
+func hasStarted(status string) bool {
+	switch status {
+	case "", "Preview", "Pre-Game", "Warmup", "Delayed Start", "Postponed",
+		"Cancelled":
+		return false
+	}
+	return true
+}
+
+// isFinal returns whether a game with the provided status has ended.
+func isFinal(status string) bool {
+	switch status {
+	case "Final", "Game Over", "Completed Early":
+		return true
+	}
+	return false
+}
+
+// isOver returns whether no more play will occur on the current date for a
+// game with the provided status: it has ended, been postponed or cancelled,
+// or been suspended.
+func isOver(status string) bool {
+	return isFinal(status) || status == "Postponed" || status == "Cancelled" ||
+		strings.HasPrefix(status, "Suspended")
+}
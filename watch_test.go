@@ -0,0 +1,276 @@
+package mlbgameday
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// snapshot is the state of a game returned by a single poll of fakeGame.
+type snapshot struct {
+	ls  *LineScore
+	abs *AtBats
+	err error
+}
+
+// fakeGame is a GameService that returns the next snapshot each time its
+// line score is retrieved.
+type fakeGame struct {
+	GameService
+
+	mu        sync.Mutex
+	snapshots []snapshot
+	n         int
+}
+
+func (g *fakeGame) current() snapshot {
+	return g.snapshots[g.n-1]
+}
+
+func (g *fakeGame) LineScore() (*LineScore, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.n < len(g.snapshots) {
+		g.n++
+	}
+	s := g.current()
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.ls, nil
+}
+
+func (g *fakeGame) AtBats() (*AtBats, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.current().abs, nil
+}
+
+func watchLineScore(status string, inning int, top string) *LineScore {
+	ls := new(LineScore)
+	ls.GID = "2016_09_05_kcamlb_minmlb_1"
+	ls.Status, ls.Inning, ls.TopInning = status, inning, top
+	return ls
+}
+
+func watchAtBat(num, pitcher, pitches int, event string, home int) AtBat {
+	ab := AtBat{AtBatSummary: AtBatSummary{
+		Number: num, Pitcher: pitcher, Event: event, HomeTeamRuns: home,
+	}}
+	for i := 0; i < pitches; i++ {
+		ab.Pitches = append(ab.Pitches, Pitch{Des: "Ball", Type: "B"})
+	}
+	return ab
+}
+
+func watchSnapshots() []snapshot {
+	inning2 := snapshot{
+		watchLineScore("In Progress", 2, "Y"),
+		&AtBats{[]AtBatInning{
+			{
+				1,
+				[]AtBat{watchAtBat(1, 100, 2, "Single", 0)},
+				[]AtBat{watchAtBat(2, 200, 1, "Home Run", 1)},
+				nil,
+				nil,
+			},
+			{Number: 2, Top: []AtBat{watchAtBat(3, 101, 0, "", 1)}},
+		}},
+		nil,
+	}
+
+	return []snapshot{
+		{watchLineScore("Preview", 0, ""), nil, nil},
+		{
+			watchLineScore("In Progress", 1, "Y"),
+			&AtBats{[]AtBatInning{
+				{Number: 1, Top: []AtBat{watchAtBat(1, 100, 1, "", 0)}},
+			}},
+			nil,
+		},
+		{
+			watchLineScore("In Progress", 1, "N"),
+			&AtBats{[]AtBatInning{
+				{
+					1,
+					[]AtBat{watchAtBat(1, 100, 2, "Single", 0)},
+					[]AtBat{watchAtBat(2, 200, 1, "Home Run", 1)},
+					nil,
+					nil,
+				},
+			}},
+			nil,
+		},
+		inning2,
+		inning2,
+		{watchLineScore("Final", 2, "Y"), inning2.abs, nil},
+	}
+}
+
+func watchOptions() *WatchOptions {
+	return &WatchOptions{
+		InPlay:         time.Millisecond,
+		BetweenInnings: time.Millisecond,
+		Idle:           time.Millisecond,
+	}
+}
+
+type watchEvent struct {
+	Type      EventType
+	Inning    int
+	TopInning string
+	AtBat     int
+	Runs      int
+	Status    string
+}
+
+func collectEvents(events <-chan Event) []watchEvent {
+	var got []watchEvent
+	for e := range events {
+		we := watchEvent{e.Type, e.Inning, e.TopInning, 0, e.Runs, e.Status}
+		if e.AtBat != nil {
+			we.AtBat = e.AtBat.Number
+		}
+		got = append(got, we)
+	}
+	return got
+}
+
+func TestWatcher(t *testing.T) {
+	game := &fakeGame{snapshots: watchSnapshots()}
+	w := NewWatcher(game, watchOptions())
+
+	got := collectEvents(w.Watch(context.Background()))
+
+	want := []watchEvent{
+		{StatusChange, 1, "Y", 0, 0, "In Progress"},
+		{InningChange, 1, "Y", 0, 0, ""},
+		{NewPitch, 1, "Y", 1, 0, ""},
+		{NewPitch, 1, "Y", 1, 0, ""},
+		{AtBatComplete, 1, "Y", 1, 0, ""},
+		{InningChange, 1, "N", 0, 0, ""},
+		{NewPitch, 1, "N", 2, 0, ""},
+		{AtBatComplete, 1, "N", 2, 0, ""},
+		{RunScored, 1, "N", 2, 1, ""},
+		{InningChange, 2, "Y", 0, 0, ""},
+		{PitchingChange, 2, "Y", 3, 0, ""},
+		{StatusChange, 2, "Y", 0, 0, "Final"},
+		{GameFinal, 2, "Y", 0, 0, "Final"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Watcher.Watch emitted\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestWatcherReplay(t *testing.T) {
+	snapshots := watchSnapshots()
+	game := &fakeGame{snapshots: snapshots[len(snapshots)-1:]}
+	opts := watchOptions()
+	opts.Replay = true
+
+	got := collectEvents(NewWatcher(game, opts).Watch(context.Background()))
+
+	var types []EventType
+	for _, e := range got {
+		types = append(types, e.Type)
+	}
+	want := []EventType{
+		StatusChange,
+		InningChange, NewPitch, NewPitch, AtBatComplete,
+		InningChange, NewPitch, AtBatComplete, RunScored,
+		InningChange, PitchingChange,
+		GameFinal,
+	}
+	if !reflect.DeepEqual(types, want) {
+		t.Errorf("Watcher.Watch emitted %v, want %v", types, want)
+	}
+}
+
+func TestWatcherStartedAfterFinal(t *testing.T) {
+	snapshots := watchSnapshots()
+	game := &fakeGame{snapshots: snapshots[len(snapshots)-1:]}
+
+	got := collectEvents(NewWatcher(game, watchOptions()).Watch(context.Background()))
+
+	want := []watchEvent{{GameFinal, 2, "Y", 0, 0, "Final"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Watcher.Watch emitted %v, want %v", got, want)
+	}
+}
+
+func TestWatcherError(t *testing.T) {
+	want := errors.New("HTTP 500")
+	game := &fakeGame{snapshots: []snapshot{{err: want}}}
+	w := NewWatcher(game, watchOptions())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := w.Watch(ctx)
+
+	for i := 0; i < 100 && w.Err() == nil; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	if err := w.Err(); err != want {
+		t.Errorf("Watcher.Err() == %v, want %v", err, want)
+	}
+
+	cancel()
+	if got := collectEvents(events); len(got) != 0 {
+		t.Errorf("Watcher.Watch emitted %v, want no events", got)
+	}
+}
+
+func TestWatcherCancel(t *testing.T) {
+	snapshots := watchSnapshots()
+	game := &fakeGame{snapshots: snapshots[:1]}
+	w := NewWatcher(game, &WatchOptions{Idle: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := w.Watch(ctx)
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Errorf("Watcher.Watch emitted an event, want none")
+		}
+	case <-time.After(time.Second):
+		t.Errorf("Watcher.Watch did not close its channel once ctx was done")
+	}
+}
+
+func TestWatcherInterval(t *testing.T) {
+	w := NewWatcher(nil, nil)
+
+	var testCases = []struct {
+		Status string
+		Outs   int
+		Want   time.Duration
+	}{
+		{"In Progress", 1, 10 * time.Second},
+		{"In Progress", 3, 30 * time.Second},
+		{"Delayed", 1, 2 * time.Minute},
+		{"Preview", 0, 2 * time.Minute},
+	}
+
+	for _, tc := range testCases {
+		g := Game{Status: tc.Status, Outs: tc.Outs}
+		if got := w.opts.interval(&g); got != tc.Want {
+			t.Errorf("WatchOptions.interval(%v, %v outs) == %v, want %v",
+				tc.Status, tc.Outs, got, tc.Want)
+		}
+	}
+}
+
+func TestEventTypeString(t *testing.T) {
+	if got := RunScored.String(); got != "RunScored" {
+		t.Errorf("RunScored.String() == %q, want %q", got, "RunScored")
+	}
+	if got := EventType(-1).String(); got != "Unknown" {
+		t.Errorf("EventType(-1).String() == %q, want %q", got, "Unknown")
+	}
+}
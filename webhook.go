@@ -0,0 +1,523 @@
+package mlbgameday
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Headers set on each webhook request.
+const (
+	EventHeader     = "X-Mlbgameday-Event"
+	SignatureHeader = "X-Mlbgameday-Signature"
+)
+
+// EventPayload is the JSON payload of an event delivered to webhooks. The
+// event type is encoded by name.
+type EventPayload struct {
+	Type      string        `json:"type"`
+	GID       string        `json:"gid"`
+	Inning    int           `json:"inning"`
+	TopInning string        `json:"top_inning,omitempty"`
+	Runs      int           `json:"runs,omitempty"`
+	Status    string        `json:"status,omitempty"`
+	AtBat     *AtBatPayload `json:"at_bat,omitempty"`
+	Pitch     *PitchPayload `json:"pitch,omitempty"`
+	Game      *GamePayload  `json:"game,omitempty"`
+}
+
+// AtBatPayload is the JSON encoding of an at-bat in an EventPayload. Its
+// pitches are not included.
+type AtBatPayload struct {
+	Number       int             `json:"num"`
+	Batter       int             `json:"batter"`
+	Pitcher      int             `json:"pitcher"`
+	Stand        string          `json:"stand,omitempty"`
+	PThrows      string          `json:"p_throws,omitempty"`
+	Balls        int             `json:"balls"`
+	Strikes      int             `json:"strikes"`
+	Outs         int             `json:"outs"`
+	Event        string          `json:"event,omitempty"`
+	Des          string          `json:"des,omitempty"`
+	EventNum     int             `json:"event_num,omitempty"`
+	AwayTeamRuns int             `json:"away_team_runs"`
+	HomeTeamRuns int             `json:"home_team_runs"`
+	Runners      []RunnerPayload `json:"runners,omitempty"`
+}
+
+// RunnerPayload is the JSON encoding of a runner movement in an
+// AtBatPayload.
+type RunnerPayload struct {
+	ID       int    `json:"id"`
+	Start    string `json:"start,omitempty"`
+	End      string `json:"end,omitempty"`
+	Event    string `json:"event,omitempty"`
+	EventNum int    `json:"event_num,omitempty"`
+	Score    bool   `json:"score,omitempty"`
+	RBI      bool   `json:"rbi,omitempty"`
+	Earned   bool   `json:"earned,omitempty"`
+}
+
+// PitchPayload is the JSON encoding of a pitch in an EventPayload.
+type PitchPayload struct {
+	Des            string  `json:"des"`
+	Type           string  `json:"type"`
+	PitchType      string  `json:"pitch_type,omitempty"`
+	TypeConfidence float32 `json:"type_confidence,omitempty"`
+	StartSpeed     float32 `json:"start_speed,omitempty"`
+	EndSpeed       float32 `json:"end_speed,omitempty"`
+	PX             float32 `json:"px,omitempty"`
+	PZ             float32 `json:"pz,omitempty"`
+	SZTop          float32 `json:"sz_top,omitempty"`
+	SZBot          float32 `json:"sz_bot,omitempty"`
+	PfxX           float32 `json:"pfx_x,omitempty"`
+	PfxZ           float32 `json:"pfx_z,omitempty"`
+	Zone           float32 `json:"zone,omitempty"`
+	SpinRate       float32 `json:"spin_rate,omitempty"`
+	SpinDir        float32 `json:"spin_dir,omitempty"`
+	EventNum       int     `json:"event_num,omitempty"`
+	TFSZulu        string  `json:"tfs_zulu,omitempty"`
+}
+
+// GamePayload is the JSON encoding of a scoreboard entry in an
+// EventPayload.
+type GamePayload struct {
+	GID            string `json:"gid"`
+	Status         string `json:"status"`
+	TimeDate       string `json:"time_date,omitempty"`
+	AwayNameAbbrev string `json:"away_name_abbrev"`
+	HomeNameAbbrev string `json:"home_name_abbrev"`
+	Inning         int    `json:"inning"`
+	TopInning      string `json:"top_inning,omitempty"`
+	Outs           int    `json:"outs"`
+	BaseState      int    `json:"base_state"`
+	AwayTeamRuns   int    `json:"away_team_runs"`
+	HomeTeamRuns   int    `json:"home_team_runs"`
+	AwayTeamHits   int    `json:"away_team_hits"`
+	HomeTeamHits   int    `json:"home_team_hits"`
+	AwayTeamErrors int    `json:"away_team_errors"`
+	HomeTeamErrors int    `json:"home_team_errors"`
+	GameNumber     int    `json:"game_number,omitempty"`
+	DoubleHeader   string `json:"double_header,omitempty"`
+}
+
+// Payload returns the payload delivered to webhooks for the event.
+func (e Event) Payload() EventPayload {
+	p := EventPayload{
+		Type:      e.Type.String(),
+		GID:       e.GID,
+		Inning:    e.Inning,
+		TopInning: e.TopInning,
+		Runs:      e.Runs,
+		Status:    e.Status,
+	}
+
+	if ab := e.AtBat; ab != nil {
+		p.AtBat = &AtBatPayload{
+			Number:       ab.Number,
+			Batter:       ab.Batter,
+			Pitcher:      ab.Pitcher,
+			Stand:        ab.Stand,
+			PThrows:      ab.PThrows,
+			Balls:        ab.Balls,
+			Strikes:      ab.Strikes,
+			Outs:         ab.Outs,
+			Event:        ab.Event,
+			Des:          ab.Des,
+			EventNum:     ab.EventNum,
+			AwayTeamRuns: ab.AwayTeamRuns,
+			HomeTeamRuns: ab.HomeTeamRuns,
+		}
+		for _, r := range ab.Runners {
+			p.AtBat.Runners = append(p.AtBat.Runners, RunnerPayload{
+				ID:       r.ID,
+				Start:    r.Start,
+				End:      r.End,
+				Event:    r.Event,
+				EventNum: r.EventNum,
+				Score:    r.Score == "T",
+				RBI:      r.RBI == "T",
+				Earned:   r.Earned == "T",
+			})
+		}
+	}
+
+	if pt := e.Pitch; pt != nil {
+		p.Pitch = &PitchPayload{
+			Des:            pt.Des,
+			Type:           pt.Type,
+			PitchType:      pt.PitchType,
+			TypeConfidence: pt.TypeConfidence,
+			StartSpeed:     pt.StartSpeed,
+			EndSpeed:       pt.EndSpeed,
+			PX:             pt.PX,
+			PZ:             pt.PZ,
+			SZTop:          pt.SZTop,
+			SZBot:          pt.SZBot,
+			PfxX:           pt.PfxX,
+			PfxZ:           pt.PfxZ,
+			Zone:           pt.Zone,
+			SpinRate:       pt.SpinRate,
+			SpinDir:        pt.SpinDir,
+			EventNum:       pt.EventNum,
+			TFSZulu:        pt.TFSZulu,
+		}
+	}
+
+	if g := e.Game; g != nil {
+		p.Game = &GamePayload{
+			GID:            g.GID,
+			Status:         g.Status,
+			TimeDate:       g.TimeDate,
+			AwayNameAbbrev: g.AwayNameAbbrev,
+			HomeNameAbbrev: g.HomeNameAbbrev,
+			Inning:         g.Inning,
+			TopInning:      g.TopInning,
+			Outs:           g.Outs,
+			BaseState:      g.BaseState,
+			AwayTeamRuns:   g.AwayTeamRuns,
+			HomeTeamRuns:   g.HomeTeamRuns,
+			AwayTeamHits:   g.AwayHitsRuns,
+			HomeTeamHits:   g.HomeHitsRuns,
+			AwayTeamErrors: g.AwayTeamErrors,
+			HomeTeamErrors: g.HomeTeamErrors,
+			GameNumber:     g.GameNumber,
+			DoubleHeader:   g.DoubleHeader,
+		}
+	}
+
+	return p
+}
+
+// MarshalJSON encodes the event as its Payload.
+func (e Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.Payload())
+}
+
+// Subscriber is a URL to which a Dispatcher delivers events.
+type Subscriber struct {
+	URL string
+
+	// Secret signs each payload with HMAC-SHA256. The signature is sent in
+	// the X-Mlbgameday-Signature header as "sha256=" followed by the hex
+	// encoded MAC. Payloads are not signed if Secret is empty.
+	Secret string
+
+	// Teams limits delivery to events of games played by these teams, which
+	// may be referred to by any key accepted by LookupFranchise. The teams
+	// of an event's game are those of its Game, or of its GID if it has no
+	// Game. Events of every game are delivered if Teams is empty.
+	Teams []string
+
+	// Types limits delivery to events of these types. Events of every type
+	// are delivered if Types is empty.
+	Types []EventType
+}
+
+// wants returns whether the event passes the subscriber's filters.
+func (s *Subscriber) wants(e *Event) bool {
+	if len(s.Types) > 0 {
+		ok := false
+		for _, t := range s.Types {
+			ok = ok || t == e.Type
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	if len(s.Teams) > 0 {
+		g := e.Game
+		if g == nil {
+			g = &Game{GID: e.GID}
+		}
+		for _, team := range s.Teams {
+			if g.HasTeam(team) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+// DeadLetter records an event that could not be delivered to a subscriber.
+type DeadLetter struct {
+	URL      string          `json:"url"`
+	Attempts int             `json:"attempts"`
+	Error    string          `json:"error"`
+	Payload  json.RawMessage `json:"payload"`
+}
+
+// ErrDispatcherClosed is returned by Dispatcher.Send after the Dispatcher
+// is closed.
+var ErrDispatcherClosed = errors.New("Dispatcher is closed")
+
+// Dispatcher delivers events to subscribers as HTTP POST requests with JSON
+// payloads. Each subscriber has its own queue of events, delivered in order
+// by its own goroutine, so that a slow or failing subscriber does not delay
+// the others. Failed deliveries are retried with exponential backoff and
+// then written to the dead-letter log.
+type Dispatcher struct {
+	// HTTP client used to deliver events.
+	client *http.Client
+
+	// MaxAttempts is the number of times delivery of an event to a subscriber
+	// is attempted. Defaults to 5.
+	MaxAttempts int
+
+	// Backoff is the delay before the first retry. It doubles for each
+	// subsequent retry. Defaults to 1 second.
+	Backoff time.Duration
+
+	// QueueSize is the number of events queued for each subscriber, taking
+	// effect for subscribers registered after it is set. Events sent to a
+	// subscriber whose queue is full are dead-lettered without being
+	// attempted. Defaults to 100.
+	QueueSize int
+
+	// DeadLetters receives a JSON encoded DeadLetter, one per line, for each
+	// event that could not be delivered. Undeliverable events are dropped if
+	// DeadLetters is nil.
+	DeadLetters io.Writer
+
+	mu          sync.Mutex
+	closed      bool
+	subscribers []*subscription
+	wg          sync.WaitGroup
+
+	// deadMu serializes writes to DeadLetters.
+	deadMu sync.Mutex
+}
+
+// subscription is a subscriber and the queue of events to deliver to it.
+type subscription struct {
+	Subscriber
+	queue chan delivery
+}
+
+// delivery is an event queued for a subscriber, to be abandoned once ctx is
+// done.
+type delivery struct {
+	ctx     context.Context
+	typ     EventType
+	payload []byte
+}
+
+// NewDispatcher returns a new Dispatcher. If a nil httpClient is provided,
+// http.DefaultClient will be used.
+func NewDispatcher(httpClient *http.Client) *Dispatcher {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Dispatcher{
+		client:      httpClient,
+		MaxAttempts: 5,
+		Backoff:     time.Second,
+		QueueSize:   100,
+	}
+}
+
+// Subscribe registers a subscriber for events sent after the call, and
+// starts the goroutine that delivers them. Subscribe has no effect after
+// Close.
+func (d *Dispatcher) Subscribe(s Subscriber) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return
+	}
+
+	size := d.QueueSize
+	if size < 1 {
+		size = 1
+	}
+	sub := &subscription{s, make(chan delivery, size)}
+	d.subscribers = append(d.subscribers, sub)
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		for dl := range sub.queue {
+			if dl.ctx.Err() == nil {
+				d.deliver(dl.ctx, &sub.Subscriber, dl.typ, dl.payload)
+			}
+		}
+	}()
+}
+
+// Dispatch sends every event received from events, as from Watcher.Watch or
+// ScoreboardWatcher.Watch, until events is closed or ctx is done. Events are
+// delivered to each subscriber in the order in which they are received.
+// Dispatch does not wait for the deliveries; see Close.
+func (d *Dispatcher) Dispatch(ctx context.Context, events <-chan Event) error {
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := d.Send(ctx, e); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Send queues the event for delivery to every subscriber whose filters it
+// passes and returns without waiting for the deliveries, which are abandoned
+// once ctx is done. Send returns an error if ctx is already done or the
+// Dispatcher is closed.
+func (d *Dispatcher) Send(ctx context.Context, e Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return ErrDispatcherClosed
+	}
+
+	for _, s := range d.subscribers {
+		if !s.wants(&e) {
+			continue
+		}
+		select {
+		case s.queue <- delivery{ctx, e.Type, payload}:
+		default:
+			d.deadLetter(DeadLetter{s.URL, 0, "Queue full", payload})
+		}
+	}
+	return nil
+}
+
+// Close stops accepting events and waits until every queued event has been
+// delivered, dead-lettered or abandoned.
+func (d *Dispatcher) Close() {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		for _, s := range d.subscribers {
+			close(s.queue)
+		}
+	}
+	d.mu.Unlock()
+
+	d.wg.Wait()
+}
+
+// deliver posts the payload to the subscriber, retrying failures until the
+// maximum number of attempts is reached, and dead-letters it if every
+// attempt fails.
+func (d *Dispatcher) deliver(ctx context.Context, s *Subscriber, t EventType, payload []byte) {
+	attempts := d.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	backoff := d.Backoff
+
+	var err error
+	n := 0
+	for n < attempts {
+		n++
+		var retry bool
+		if retry, err = d.post(ctx, s, t, payload); err == nil || !retry {
+			break
+		}
+		if n == attempts {
+			break
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+		backoff *= 2
+	}
+
+	if err != nil && ctx.Err() == nil {
+		d.deadLetter(DeadLetter{s.URL, n, err.Error(), payload})
+	}
+}
+
+// post makes a single delivery attempt and returns whether a failed attempt
+// should be retried. Requests rejected with a 4xx status other than 429 are
+// not retried.
+func (d *Dispatcher) post(ctx context.Context, s *Subscriber, t EventType, payload []byte) (bool, error) {
+	req, err := http.NewRequest("POST", s.URL, bytes.NewReader(payload))
+	if err != nil {
+		return false, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, t.String())
+	if s.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign([]byte(s.Secret), payload))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry := resp.StatusCode >= 500 || resp.StatusCode == 429
+	return retry, &HTTPError{resp.StatusCode}
+}
+
+func (d *Dispatcher) deadLetter(dl DeadLetter) {
+	if d.DeadLetters == nil {
+		return
+	}
+
+	data, err := json.Marshal(dl)
+	if err != nil {
+		return
+	}
+
+	d.deadMu.Lock()
+	defer d.deadMu.Unlock()
+	fmt.Fprintf(d.DeadLetters, "%s\n", data)
+}
+
+// Sign returns the signature of a webhook payload signed with the provided
+// secret, in the form sent in the X-Mlbgameday-Signature header.
+func Sign(secret, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature returns whether signature is the signature of the payload
+// signed with the provided secret. Receivers of webhooks should verify the
+// X-Mlbgameday-Signature header of each request.
+func VerifySignature(secret, payload []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, payload)), []byte(signature))
+}
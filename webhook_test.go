@@ -0,0 +1,348 @@
+package mlbgameday
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// receiver records the webhook requests made to it and responds with the
+// next of its status codes, repeating the last.
+type receiver struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	bodies   [][]byte
+	headers  []http.Header
+}
+
+func newReceiver(t *testing.T, statuses ...int) *receiver {
+	r := &receiver{statuses: statuses}
+	r.Server = httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, req *http.Request) {
+			testMethod(t, req, "POST")
+			body, _ := ioutil.ReadAll(req.Body)
+
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.bodies = append(r.bodies, body)
+			r.headers = append(r.headers, req.Header)
+
+			status := http.StatusOK
+			if len(r.statuses) > 0 {
+				status = r.statuses[0]
+				if len(r.statuses) > 1 {
+					r.statuses = r.statuses[1:]
+				}
+			}
+			w.WriteHeader(status)
+		}))
+	return r
+}
+
+func (r *receiver) requests() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.bodies)
+}
+
+func webhookEvents() []Event {
+	kc := watchGame("2016_09_05_kcamlb_minmlb_1", "In Progress", 1, "N", 1, 0)
+	nyy := watchGame("2016_09_05_tormlb_nyamlb_1", "Final", 9, "N", 2, 7)
+	nyy.AwayNameAbbrev, nyy.HomeNameAbbrev = "TOR", "NYY"
+	return []Event{
+		{Type: ScoreChange, GID: kc.GID, Inning: 1, TopInning: "N", Runs: 1, Game: &kc},
+		{Type: GameFinal, GID: nyy.GID, Inning: 9, TopInning: "N", Status: "Final", Game: &nyy},
+		{Type: NewPitch, GID: kc.GID, Inning: 1, TopInning: "N",
+			Pitch: &Pitch{Des: "Ball", Type: "B"}},
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	all := newReceiver(t)
+	defer all.Close()
+	royals := newReceiver(t)
+	defer royals.Close()
+	finals := newReceiver(t)
+	defer finals.Close()
+
+	d := NewDispatcher(nil)
+	d.Subscribe(Subscriber{URL: all.URL, Secret: "s3cret"})
+	d.Subscribe(Subscriber{URL: royals.URL, Teams: []string{"KC"}})
+	d.Subscribe(Subscriber{URL: finals.URL, Types: []EventType{GameFinal}})
+
+	events := make(chan Event)
+	go func() {
+		for _, e := range webhookEvents() {
+			events <- e
+		}
+		close(events)
+	}()
+
+	if err := d.Dispatch(context.Background(), events); err != nil {
+		t.Fatalf("Dispatcher.Dispatch returned error: %v", err)
+	}
+	d.Close()
+
+	if got := all.requests(); got != 3 {
+		t.Errorf("Subscriber received %v events, want 3", got)
+	}
+	if got := royals.requests(); got != 2 {
+		t.Fatalf("Team subscriber received %v events, want 2", got)
+	}
+	if got := royals.headers[1].Get(EventHeader); got != "NewPitch" {
+		t.Errorf("Team subscriber received %v, want the NewPitch of the KC game", got)
+	}
+	if got := finals.requests(); got != 1 {
+		t.Errorf("Type subscriber received %v events, want 1", got)
+	}
+
+	body, h := all.bodies[0], all.headers[0]
+	if !VerifySignature([]byte("s3cret"), body, h.Get(SignatureHeader)) {
+		t.Errorf("Signature %q does not verify", h.Get(SignatureHeader))
+	}
+	if got := h.Get(EventHeader); got != "ScoreChange" {
+		t.Errorf("%v header is %q, want %q", EventHeader, got, "ScoreChange")
+	}
+	if got := h.Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type header is %q, want application/json", got)
+	}
+	if royals.headers[0].Get(SignatureHeader) != "" {
+		t.Errorf("Payload to subscriber without a secret was signed")
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("Could not decode payload: %v", err)
+	}
+	if payload["type"] != "ScoreChange" || payload["runs"] != 1.0 ||
+		payload["gid"] != "2016_09_05_kcamlb_minmlb_1" {
+		t.Errorf("Payload is %v, want a ScoreChange of 1 run", payload)
+	}
+	game, _ := payload["game"].(map[string]interface{})
+	if game["away_name_abbrev"] != "KC" || game["away_team_runs"] != 1.0 {
+		t.Errorf("Payload game is %v, want KC leading 1-0", payload["game"])
+	}
+
+	var pitch EventPayload
+	if err := json.Unmarshal(all.bodies[2], &pitch); err != nil {
+		t.Fatalf("Could not decode payload: %v", err)
+	}
+	if pitch.Type != "NewPitch" || pitch.Pitch == nil || pitch.Pitch.Des != "Ball" ||
+		pitch.Game != nil {
+		t.Errorf("Payload is %+v, want a NewPitch of a ball", pitch)
+	}
+}
+
+func TestDispatcherStatsAPI(t *testing.T) {
+	setup()
+	defer teardown()
+
+	game, err := setupStatsAPI(t).Game("448916")
+	if err != nil {
+		t.Fatalf("Gameday.Game returned error: %v", err)
+	}
+	w := NewWatcher(game, &WatchOptions{Replay: true})
+	events, _, _ := w.poll()
+	if len(events) == 0 || events[0].GID != "448916" {
+		t.Fatalf("Watcher.poll returned %v, want events of game 448916", events)
+	}
+
+	// The GID of Stats API games is a gamePk without team codes.
+	royals := newReceiver(t)
+	defer royals.Close()
+	yankees := newReceiver(t)
+	defer yankees.Close()
+
+	d := NewDispatcher(nil)
+	d.Subscribe(Subscriber{URL: royals.URL, Teams: []string{"118"}})
+	d.Subscribe(Subscriber{URL: yankees.URL, Teams: []string{"NYY"}})
+	for _, e := range events {
+		if err := d.Send(context.Background(), e); err != nil {
+			t.Fatalf("Dispatcher.Send returned error: %v", err)
+		}
+	}
+	d.Close()
+
+	if got := royals.requests(); got != len(events) {
+		t.Errorf("Team subscriber received %v events, want %v", got, len(events))
+	}
+	if got := yankees.requests(); got != 0 {
+		t.Errorf("Subscriber of another team received %v events, want 0", got)
+	}
+}
+
+func TestDispatcherRetry(t *testing.T) {
+	r := newReceiver(t, 500, 503, 200)
+	defer r.Close()
+
+	var dead bytes.Buffer
+	d := NewDispatcher(nil)
+	d.Backoff = time.Millisecond
+	d.DeadLetters = &dead
+	d.Subscribe(Subscriber{URL: r.URL})
+
+	if err := d.Send(context.Background(), webhookEvents()[0]); err != nil {
+		t.Fatalf("Dispatcher.Send returned error: %v", err)
+	}
+	d.Close()
+
+	if got := r.requests(); got != 3 {
+		t.Errorf("Subscriber received %v requests, want 3", got)
+	}
+	if dead.Len() != 0 {
+		t.Errorf("Dispatcher dead-lettered %q, want nothing", dead.String())
+	}
+}
+
+func TestDispatcherDeadLetter(t *testing.T) {
+	r := newReceiver(t, 500)
+	defer r.Close()
+	rejected := newReceiver(t, 400)
+	defer rejected.Close()
+
+	var dead bytes.Buffer
+	d := NewDispatcher(nil)
+	d.MaxAttempts = 3
+	d.Backoff = time.Millisecond
+	d.DeadLetters = &dead
+	d.Subscribe(Subscriber{URL: r.URL})
+	d.Subscribe(Subscriber{URL: rejected.URL})
+
+	if err := d.Send(context.Background(), webhookEvents()[1]); err != nil {
+		t.Fatalf("Dispatcher.Send returned error: %v", err)
+	}
+	d.Close()
+
+	if got := r.requests(); got != 3 {
+		t.Errorf("Failing subscriber received %v requests, want 3", got)
+	}
+	if got := rejected.requests(); got != 1 {
+		t.Errorf("Rejecting subscriber received %v requests, want 1", got)
+	}
+
+	got := make(map[string]DeadLetter)
+	dec := json.NewDecoder(&dead)
+	for dec.More() {
+		var dl DeadLetter
+		if err := dec.Decode(&dl); err != nil {
+			t.Fatalf("Could not decode dead letter: %v", err)
+		}
+		got[dl.URL] = dl
+	}
+
+	if dl := got[r.URL]; dl.Attempts != 3 || dl.Error != "HTTP 500" {
+		t.Errorf("Dead letter is %+v, want 3 attempts failing with HTTP 500", dl)
+	}
+	if dl := got[rejected.URL]; dl.Attempts != 1 || dl.Error != "HTTP 400" {
+		t.Errorf("Dead letter is %+v, want 1 attempt failing with HTTP 400", dl)
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(got[r.URL].Payload, &payload); err != nil ||
+		payload["type"] != "GameFinal" {
+		t.Errorf("Dead letter payload is %s, want a GameFinal event",
+			got[r.URL].Payload)
+	}
+}
+
+func TestDispatcherCancel(t *testing.T) {
+	r := newReceiver(t, 500)
+	defer r.Close()
+
+	var dead bytes.Buffer
+	d := NewDispatcher(nil)
+	d.Backoff = time.Hour
+	d.DeadLetters = &dead
+	d.Subscribe(Subscriber{URL: r.URL})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := d.Send(ctx, webhookEvents()[0]); err != nil {
+		t.Fatalf("Dispatcher.Send returned error: %v", err)
+	}
+	for r.requests() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	d.Close()
+
+	if dead.Len() != 0 {
+		t.Errorf("Dispatcher dead-lettered %q after cancellation", dead.String())
+	}
+	err := d.Send(context.Background(), webhookEvents()[0])
+	testError(t, "Dispatcher.Send", ErrDispatcherClosed.Error(), err)
+	err = d.Send(ctx, webhookEvents()[0])
+	testError(t, "Dispatcher.Send", context.Canceled.Error(), err)
+}
+
+func TestDispatcherFailingSubscriber(t *testing.T) {
+	down := newReceiver(t, 500)
+	defer down.Close()
+	up := newReceiver(t)
+	defer up.Close()
+
+	var dead bytes.Buffer
+	d := NewDispatcher(nil)
+	d.Backoff = time.Hour
+	d.DeadLetters = &dead
+	d.Subscribe(Subscriber{URL: up.URL})
+	d.QueueSize = 1
+	d.Subscribe(Subscriber{URL: down.URL})
+
+	// The failing subscriber waits an hour to retry the first event, while
+	// the other receives every event at once.
+	ctx, cancel := context.WithCancel(context.Background())
+	events := webhookEvents()
+	if err := d.Send(ctx, events[0]); err != nil {
+		t.Fatalf("Dispatcher.Send returned error: %v", err)
+	}
+	for down.requests() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	for _, e := range events[1:] {
+		if err := d.Send(ctx, e); err != nil {
+			t.Fatalf("Dispatcher.Send returned error: %v", err)
+		}
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for up.requests() < len(events) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := up.requests(); got != len(events) {
+		t.Errorf("Subscriber received %v events while another failed, want %v",
+			got, len(events))
+	}
+
+	cancel()
+	d.Close()
+
+	// The second event filled the failing subscriber's queue.
+	var dl DeadLetter
+	if err := json.Unmarshal(dead.Bytes(), &dl); err != nil ||
+		dl.URL != down.URL || dl.Attempts != 0 || dl.Error != "Queue full" {
+		t.Errorf("Dispatcher dead-lettered %q, want the third event with a full queue",
+			dead.String())
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	secret, payload := []byte("s3cret"), []byte(`{"type":"GameFinal"}`)
+	sig := Sign(secret, payload)
+
+	if !VerifySignature(secret, payload, sig) {
+		t.Errorf("VerifySignature rejected a valid signature")
+	}
+	if VerifySignature([]byte("other"), payload, sig) {
+		t.Errorf("VerifySignature accepted a signature made with another secret")
+	}
+	if VerifySignature(secret, []byte(`{}`), sig) {
+		t.Errorf("VerifySignature accepted a signature of another payload")
+	}
+}
@@ -0,0 +1,392 @@
+// Package winexp computes win probability, win probability added (WPA) and
+// leverage index from MLB Gameday at-bats.
+//
+// A Model is a table of the games observed in each situation, keyed by
+// inning, half inning, outs, runners on base and score differential, and of
+// how many of them the home team won. Build one from historical games and
+// use it to compute the win probability curve of any game.
+package winexp
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/ericdreeves/mlbgameday"
+	"github.com/ericdreeves/mlbgameday/runexp"
+)
+
+// ExtraInnings is the Inning of the Key of every extra inning.
+const ExtraInnings = 10
+
+// MaxDiff is the largest score differential kept in a Key. Larger leads are
+// treated as leads of MaxDiff.
+const MaxDiff = 10
+
+// Key identifies a situation in a game.
+type Key struct {
+	// Inning is the inning, or ExtraInnings for any inning after the 9th.
+	Inning    int
+	TopInning bool
+
+	// State holds the outs and runners on base.
+	State runexp.State
+
+	// Diff is the home team's runs minus the away team's, between -MaxDiff
+	// and MaxDiff.
+	Diff int
+}
+
+// KeyOf returns the key of a game state. It returns false if the half inning
+// is over.
+func KeyOf(s mlbgameday.GameState) (Key, bool) {
+	st, ok := runexp.StateOf(s)
+	if !ok {
+		return Key{}, false
+	}
+	return newKey(s.Inning, s.TopInning, st, s.HomeRuns-s.AwayRuns), true
+}
+
+// newKey returns the key of a situation, folding extra innings and large
+// leads.
+func newKey(inning int, top bool, st runexp.State, diff int) Key {
+	if inning > 9 {
+		inning = ExtraInnings
+	}
+	if diff > MaxDiff {
+		diff = MaxDiff
+	} else if diff < -MaxDiff {
+		diff = -MaxDiff
+	}
+	return Key{inning, top, st, diff}
+}
+
+// cell holds the games observed in a situation.
+type cell struct {
+	Games    int
+	HomeWins int
+}
+
+// Model represents a win expectancy table. The zero Model is empty, with a
+// PriorWeight of 0, and ready to use; NewModel returns one with the default
+// PriorWeight. A Model is safe for concurrent use, except that Add and
+// UnmarshalJSON must not be called concurrently with other methods.
+type Model struct {
+	// PriorWeight is the number of games with which the prior estimate of a
+	// situation's win probability is weighted against the games observed in
+	// it. The prior is a normal approximation of the remaining runs scored
+	// by each team, used alone for situations that were never observed.
+	PriorWeight float64
+
+	cells map[Key]*cell
+
+	// swing caches the average swing of the observed situations, guarded by
+	// mu. See Leverage.
+	mu    sync.Mutex
+	swing float64
+}
+
+// NewModel returns a new empty Model.
+func NewModel() *Model {
+	return &Model{PriorWeight: 5, cells: make(map[Key]*cell)}
+}
+
+// Build returns the model of a set of games.
+func Build(games ...*mlbgameday.AtBats) *Model {
+	m := NewModel()
+	for _, abs := range games {
+		m.Add(abs)
+	}
+	return m
+}
+
+// Add adds the situation at the start of every at-bat of a game, and the
+// game's result, to the model. Games that did not end with a winner are
+// skipped.
+func (m *Model) Add(abs *mlbgameday.AtBats) {
+	var keys []Key
+	var final mlbgameday.GameState
+	mlbgameday.WalkGameStates(abs, func(step mlbgameday.GameStep) error {
+		final = step.After
+		if step.Kind == mlbgameday.PlateAppearanceStep {
+			if k, ok := KeyOf(step.Before); ok {
+				keys = append(keys, k)
+			}
+		}
+		return nil
+	})
+	if final.HomeRuns == final.AwayRuns {
+		return
+	}
+
+	if m.cells == nil {
+		m.cells = make(map[Key]*cell)
+	}
+	for _, k := range keys {
+		c, ok := m.cells[k]
+		if !ok {
+			c = new(cell)
+			m.cells[k] = c
+		}
+		c.Games++
+		if final.HomeRuns > final.AwayRuns {
+			c.HomeWins++
+		}
+	}
+
+	m.mu.Lock()
+	m.swing = 0
+	m.mu.Unlock()
+}
+
+// WinProbability returns the probability that the home team wins from a game
+// state. States at the end of a half inning are evaluated at the start of the
+// next one, and those in which the game is over return 0 or 1.
+func (m *Model) WinProbability(s mlbgameday.GameState) float64 {
+	st, _ := runexp.StateOf(s)
+	return m.probability(s.Inning, s.TopInning, s.Outs, st.Bases,
+		s.HomeRuns-s.AwayRuns)
+}
+
+// probability returns the probability that the home team wins from a
+// situation, which may be the end of a half inning or of the game.
+func (m *Model) probability(inning int, top bool, outs, bases, diff int) float64 {
+	switch {
+	case !top && inning >= 9 && diff > 0:
+		// Walk-off.
+		return 1
+	case outs < 3:
+		return m.lookup(newKey(inning, top, runexp.State{Bases: bases, Outs: outs}, diff))
+	case top && inning >= 9 && diff > 0:
+		return 1
+	case !top && inning >= 9 && diff < 0:
+		return 0
+	case top:
+		return m.probability(inning, false, 0, 0, diff)
+	}
+	return m.probability(inning+1, true, 0, 0, diff)
+}
+
+// lookup returns the win probability of a situation, combining the games
+// observed in it with the prior.
+func (m *Model) lookup(k Key) float64 {
+	p := prior(k)
+	c, ok := m.cells[k]
+	if !ok {
+		return p
+	}
+	return (float64(c.HomeWins) + m.PriorWeight*p) /
+		(float64(c.Games) + m.PriorWeight)
+}
+
+// runVariance is the variance of the runs scored by a team in a half inning.
+const runVariance = 1.0
+
+// prior returns the probability that the home team wins from a situation
+// under a normal approximation of the runs scored by each team in their
+// remaining half innings. Ties at the end of regulation are even.
+func prior(k Key) float64 {
+	inning := k.Inning
+	if inning > 9 {
+		inning = 9
+	}
+
+	away, home := float64(9-inning), float64(9-inning)
+	rest := float64(3-k.State.Outs) / 3
+	if k.TopInning {
+		away += rest
+		home++
+	} else {
+		home += rest
+	}
+
+	sd := math.Sqrt(runVariance * (away + home))
+	if sd == 0 {
+		switch {
+		case k.Diff > 0:
+			return 1
+		case k.Diff < 0:
+			return 0
+		}
+		return 0.5
+	}
+	return 0.5 * math.Erfc(-float64(k.Diff)/(sd*math.Sqrt2))
+}
+
+// Leverage returns the leverage index of a game state: how much the result
+// of the next at-bat can swing the win probability relative to the average
+// at-bat of the games in the model. The swing of a situation is the
+// difference in win probability between a home run and an out.
+func (m *Model) Leverage(s mlbgameday.GameState) float64 {
+	avg := m.averageSwing()
+	if avg == 0 {
+		return 1
+	}
+	st, _ := runexp.StateOf(s)
+	return m.swingOf(s.Inning, s.TopInning, st, s.HomeRuns-s.AwayRuns) / avg
+}
+
+// swingOf returns the difference in win probability between a home run and
+// an out in a situation.
+func (m *Model) swingOf(inning int, top bool, st runexp.State, diff int) float64 {
+	runs := 1
+	for i := uint(0); i < 3; i++ {
+		if st.Bases&(1<<i) != 0 {
+			runs++
+		}
+	}
+	if top {
+		runs = -runs
+	}
+
+	hr := m.probability(inning, top, st.Outs, 0, diff+runs)
+	out := m.probability(inning, top, st.Outs+1, st.Bases, diff)
+	return math.Abs(hr - out)
+}
+
+// averageSwing returns the average swing of the situations in the model,
+// weighted by the number of games observed in each.
+func (m *Model) averageSwing() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.swing != 0 {
+		return m.swing
+	}
+
+	total, games := 0.0, 0
+	for k, c := range m.cells {
+		total += float64(c.Games) * m.swingOf(k.Inning, k.TopInning, k.State, k.Diff)
+		games += c.Games
+	}
+	if games > 0 {
+		m.swing = total / float64(games)
+	}
+	return m.swing
+}
+
+// Point represents the win probability of the home team before and after an
+// at-bat.
+type Point struct {
+	AtBat     *mlbgameday.AtBat
+	Inning    int
+	TopInning bool
+	Before    float64
+	After     float64
+
+	// WPA is the win probability added by the at-bat for the batting team.
+	WPA float64
+
+	// Leverage is the leverage index at the start of the at-bat.
+	Leverage float64
+}
+
+// Curve returns the win probability curve of a game, with the WPA and
+// leverage index of each at-bat.
+func (m *Model) Curve(game mlbgameday.GameService) ([]Point, error) {
+	abs, err := game.AtBats()
+	if err != nil {
+		return nil, err
+	}
+	return m.Points(abs), nil
+}
+
+// Points returns the win probability curve of the at-bats of a game. The WPA
+// of an at-bat includes the actions, such as stolen bases, that occurred
+// during it.
+func (m *Model) Points(abs *mlbgameday.AtBats) []Point {
+	var points []Point
+	mlbgameday.WalkGameStates(abs, func(step mlbgameday.GameStep) error {
+		if step.Kind != mlbgameday.PlateAppearanceStep {
+			return nil
+		}
+
+		p := Point{
+			AtBat:     step.AtBat,
+			Inning:    step.Before.Inning,
+			TopInning: step.Before.TopInning,
+			Before:    m.WinProbability(step.Before),
+			After:     m.WinProbability(step.After),
+			Leverage:  m.Leverage(step.Before),
+		}
+		p.WPA = p.After - p.Before
+		if p.TopInning {
+			p.WPA = -p.WPA
+		}
+		points = append(points, p)
+		return nil
+	})
+
+	return points
+}
+
+// modelCell is the serialized form of a single cell of a Model.
+type modelCell struct {
+	Inning   int  `json:"inning"`
+	Top      bool `json:"top"`
+	Outs     int  `json:"outs"`
+	Bases    int  `json:"bases"`
+	Diff     int  `json:"diff"`
+	Games    int  `json:"games"`
+	HomeWins int  `json:"home_wins"`
+}
+
+// MarshalJSON encodes the model as its prior weight and a list of the
+// observed situations, ordered by key, with their games and home wins.
+// Bases are encoded as in runexp.State.
+func (m *Model) MarshalJSON() ([]byte, error) {
+	cells := make([]modelCell, 0, len(m.cells))
+	for k, c := range m.cells {
+		cells = append(cells, modelCell{
+			k.Inning, k.TopInning, k.State.Outs, k.State.Bases, k.Diff,
+			c.Games, c.HomeWins,
+		})
+	}
+	sort.Slice(cells, func(i, j int) bool {
+		a, b := cells[i], cells[j]
+		switch {
+		case a.Inning != b.Inning:
+			return a.Inning < b.Inning
+		case a.Top != b.Top:
+			return a.Top
+		case a.Outs != b.Outs:
+			return a.Outs < b.Outs
+		case a.Bases != b.Bases:
+			return a.Bases < b.Bases
+		}
+		return a.Diff < b.Diff
+	})
+
+	return json.Marshal(struct {
+		PriorWeight float64     `json:"prior_weight"`
+		Cells       []modelCell `json:"cells"`
+	}{m.PriorWeight, cells})
+}
+
+// UnmarshalJSON decodes a model encoded by MarshalJSON.
+func (m *Model) UnmarshalJSON(data []byte) error {
+	var v struct {
+		PriorWeight float64     `json:"prior_weight"`
+		Cells       []modelCell `json:"cells"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	m.PriorWeight = v.PriorWeight
+	m.cells = make(map[Key]*cell)
+	m.mu.Lock()
+	m.swing = 0
+	m.mu.Unlock()
+	for _, c := range v.Cells {
+		if c.Inning < 1 || c.Inning > ExtraInnings || c.Outs < 0 ||
+			c.Outs > 2 || c.Bases < 0 || c.Bases > 7 {
+			return fmt.Errorf("Invalid situation %+v", c)
+		}
+		k := newKey(c.Inning, c.Top, runexp.State{Bases: c.Bases, Outs: c.Outs}, c.Diff)
+		m.cells[k] = &cell{c.Games, c.HomeWins}
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package winexp
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/ericdreeves/mlbgameday"
+	"github.com/ericdreeves/mlbgameday/internal/testutil"
+	"github.com/ericdreeves/mlbgameday/runexp"
+)
+
+func approx(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestKeyOf(t *testing.T) {
+	s := mlbgameday.GameState{
+		Inning: 12, Outs: 1, Bases: mlbgameday.Bases{0, 1, 0},
+		AwayRuns: 14, HomeRuns: 2,
+	}
+	k, ok := KeyOf(s)
+	want := Key{ExtraInnings, false, runexp.State{Bases: 2, Outs: 1}, -MaxDiff}
+	if !ok || k != want {
+		t.Errorf("KeyOf returned %+v, want %+v", k, want)
+	}
+
+	s.Outs = 3
+	if _, ok := KeyOf(s); ok {
+		t.Errorf("KeyOf returned a key for a state with 3 outs")
+	}
+}
+
+func TestWinProbability(t *testing.T) {
+	m := NewModel()
+
+	var testCases = []struct {
+		State mlbgameday.GameState
+		Want  float64
+	}{
+		{mlbgameday.GameState{Inning: 1, TopInning: true}, 0.5},
+		{mlbgameday.GameState{Inning: 9, Outs: 1, HomeRuns: 1}, 1},
+		{mlbgameday.GameState{Inning: 9, TopInning: true, Outs: 3, HomeRuns: 1}, 1},
+		{mlbgameday.GameState{Inning: 11, Outs: 3, AwayRuns: 1}, 0},
+		{mlbgameday.GameState{Inning: 9, Outs: 3, AwayRuns: 1, HomeRuns: 1}, 0.5},
+	}
+	for _, tc := range testCases {
+		if p := m.WinProbability(tc.State); !approx(p, tc.Want) {
+			t.Errorf("Model.WinProbability(%+v) returned %v, want %v",
+				tc.State, p, tc.Want)
+		}
+	}
+
+	lead := mlbgameday.GameState{Inning: 5, TopInning: true, HomeRuns: 2}
+	late := lead
+	late.Inning = 8
+	if p, q := m.WinProbability(lead), m.WinProbability(late); p <= 0.5 || q <= p {
+		t.Errorf("Model.WinProbability of a 2-run lead returned %v in the 5th and %v in the 8th, want increasing above 0.5",
+			p, q)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	abs := testutil.AtBats(t)
+	m := Build(abs)
+
+	// The away team won, so every observed situation favors it.
+	start := mlbgameday.GameState{Inning: 1, TopInning: true}
+	if p := m.WinProbability(start); !approx(p, 2.5/6) {
+		t.Errorf("Model.WinProbability at the start returned %v, want %v", p, 2.5/6)
+	}
+
+	games := 0
+	for _, c := range m.cells {
+		games += c.Games
+		if c.HomeWins != 0 {
+			t.Errorf("Build observed %v home wins, want 0", c.HomeWins)
+		}
+	}
+	if games != 88 {
+		t.Errorf("Build observed %v situations, want 88", games)
+	}
+
+	// Tied games are skipped.
+	tied := &mlbgameday.AtBats{Innings: []mlbgameday.AtBatInning{{
+		Number: 1,
+		Top:    []mlbgameday.AtBat{{Pitches: []mlbgameday.Pitch{{Type: "X"}}}},
+	}}}
+	if m := Build(tied); len(m.cells) != 0 {
+		t.Errorf("Build observed %v situations of a tied game, want 0", len(m.cells))
+	}
+
+	// The zero Model is ready to use.
+	var zero Model
+	zero.Add(abs)
+	if len(zero.cells) != len(m.cells) {
+		t.Errorf("Model.Add observed %v situations in a zero Model, want %v",
+			len(zero.cells), len(m.cells))
+	}
+}
+
+func TestLeverageConcurrent(t *testing.T) {
+	// Adding a game resets the cached average swing.
+	abs := testutil.AtBats(t)
+	m := Build(abs)
+	s := mlbgameday.GameState{Inning: 9, Outs: 2, HomeRuns: 3, AwayRuns: 4}
+	m.Leverage(s)
+	m.Add(abs)
+	want := Build(abs, abs).Leverage(s)
+
+	results := make(chan float64)
+	for i := 0; i < 4; i++ {
+		go func() {
+			results <- m.Leverage(s)
+		}()
+	}
+	for i := 0; i < 4; i++ {
+		if got := <-results; !approx(got, want) {
+			t.Errorf("Model.Leverage returned %v, want %v", got, want)
+		}
+	}
+}
+
+func TestCurve(t *testing.T) {
+	abs := testutil.AtBats(t)
+	m := Build(abs)
+
+	points, err := m.Curve(&testutil.Game{Plays: abs})
+	if err != nil {
+		t.Fatalf("Model.Curve returned error: %v", err)
+	}
+	if len(points) != 88 {
+		t.Fatalf("Model.Curve returned %v points, want 88", len(points))
+	}
+
+	last := points[len(points)-1]
+	if last.After != 0 {
+		t.Errorf("Model.Curve ended with home win probability %v, want 0", last.After)
+	}
+
+	leverage, wpa := 0.0, 0.0
+	for _, p := range points {
+		leverage += p.Leverage
+		if p.TopInning {
+			wpa -= p.WPA
+		} else {
+			wpa += p.WPA
+		}
+	}
+	if avg := leverage / float64(len(points)); !approx(avg, 1) {
+		t.Errorf("Model.Curve returned average leverage %v, want 1", avg)
+	}
+	if change := last.After - points[0].Before; math.Abs(wpa-change) > 0.2 {
+		t.Errorf("Model.Curve returned home WPA %v, want near %v", wpa, change)
+	}
+
+	// A three-run home run with two outs in the 5th.
+	hr := points[40]
+	if hr.AtBat.Number != 41 || hr.WPA <= 0 || hr.After >= hr.Before {
+		t.Errorf("Model.Curve returned %+v for at-bat 41, want positive WPA for the away team",
+			hr)
+	}
+
+	_, err = m.Curve(&testutil.Game{Err: errors.New("Not found")})
+	if err == nil || err.Error() != "Not found" {
+		t.Errorf("Model.Curve returned error %v, want Not found", err)
+	}
+}
+
+func TestModelJSON(t *testing.T) {
+	m := Build(testutil.AtBats(t))
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+
+	got := new(Model)
+	if err := json.Unmarshal(data, got); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, m) {
+		t.Errorf("json.Unmarshal returned %v, want %v", got, m)
+	}
+
+	err = json.Unmarshal([]byte(`{"cells":[{"inning":1,"outs":3}]}`), new(Model))
+	if err == nil {
+		t.Errorf("json.Unmarshal returned no error for 3 outs")
+	}
+}
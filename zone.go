@@ -0,0 +1,54 @@
+package mlbgameday
+
+import "math"
+
+// plateHalfWidth is half the width of home plate, in feet.
+const plateHalfWidth = 17.0 / 24
+
+// Zone represents the strike zone by which called pitches are judged: the
+// rulebook zone, which spans the width of home plate and extends from SZBot
+// to SZTop, widened by the radius of the ball so that a pitch touching it is
+// a strike, and by optional margins.
+type Zone struct {
+	// Margin widens the zone to each side, and VerticalMargin above and
+	// below, in feet. Negative margins narrow it.
+	Margin         float64
+	VerticalMargin float64
+}
+
+// Contains returns whether the pitch crossed the zone. It returns false for
+// pitches without a location.
+func (z Zone) Contains(p *Pitch) bool {
+	return p.HasLocation() && z.Distance(p) <= 0
+}
+
+// Distance returns the distance, in feet, from the edge of the zone to the
+// center of the ball as it crossed the front of home plate. It is negative
+// for pitches inside the zone.
+func (z Zone) Distance(p *Pitch) float64 {
+	dx := math.Abs(float64(p.PX)) - (plateHalfWidth + ballRadius + z.Margin)
+	top := float64(p.SZTop) + ballRadius + z.VerticalMargin
+	bottom := float64(p.SZBot) - ballRadius - z.VerticalMargin
+	dz := math.Max(float64(p.PZ)-top, bottom-float64(p.PZ))
+
+	if dx > 0 && dz > 0 {
+		return math.Hypot(dx, dz)
+	}
+	return math.Max(dx, dz)
+}
+
+// HasLocation returns whether the pitch has a location and a strike zone.
+func (p *Pitch) HasLocation() bool {
+	return p.SZTop > p.SZBot
+}
+
+// IsCalled returns whether the pitch was a ball or strike called by the
+// umpire, as opposed to a swing, a pitch that hit the batter or an
+// intentional ball.
+func (p *Pitch) IsCalled() bool {
+	switch p.Des {
+	case "Called Strike", "Ball", "Ball In Dirt":
+		return true
+	}
+	return false
+}
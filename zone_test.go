@@ -0,0 +1,49 @@
+package mlbgameday
+
+import (
+	"math"
+	"testing"
+)
+
+func TestZone(t *testing.T) {
+	var testCases = []struct {
+		Zone     Zone
+		Pitch    Pitch
+		Contains bool
+		Distance float64
+	}{
+		{Zone{}, Pitch{PX: 0, PZ: 2.5, SZTop: 3.5, SZBot: 1.5}, true, -plateHalfWidth - ballRadius},
+		{Zone{}, Pitch{PX: 0.8, PZ: 2.5, SZTop: 3.5, SZBot: 1.5}, true, 0.8 - plateHalfWidth - ballRadius},
+		{Zone{}, Pitch{PX: -0.9, PZ: 2.5, SZTop: 3.5, SZBot: 1.5}, false, 0.9 - plateHalfWidth - ballRadius},
+		{Zone{Margin: 0.1}, Pitch{PX: -0.9, PZ: 2.5, SZTop: 3.5, SZBot: 1.5}, true, 0.8 - plateHalfWidth - ballRadius},
+		{Zone{VerticalMargin: -0.5}, Pitch{PX: 0, PZ: 3.2, SZTop: 3.5, SZBot: 1.5}, false, 3.2 - 3 - ballRadius},
+		{Zone{}, Pitch{PX: plateHalfWidth + ballRadius + 0.3, PZ: 3.9 + ballRadius, SZTop: 3.5, SZBot: 1.5}, false, 0.5},
+		{Zone{}, Pitch{PX: 0, PZ: 2.5}, false, 0},
+	}
+	for i, tc := range testCases {
+		if got := tc.Zone.Contains(&tc.Pitch); got != tc.Contains {
+			t.Errorf("Zone.Contains returned %v for case %v, want %v", got, i, tc.Contains)
+		}
+		if !tc.Pitch.HasLocation() {
+			continue
+		}
+		if got := tc.Zone.Distance(&tc.Pitch); math.Abs(got-tc.Distance) > 1e-6 {
+			t.Errorf("Zone.Distance returned %v for case %v, want %v", got, i, tc.Distance)
+		}
+	}
+}
+
+func TestPitchIsCalled(t *testing.T) {
+	for des, want := range map[string]bool{
+		"Called Strike":   true,
+		"Ball":            true,
+		"Ball In Dirt":    true,
+		"Swinging Strike": false,
+		"Hit By Pitch":    false,
+		"Intent Ball":     false,
+	} {
+		if got := (&Pitch{Des: des}).IsCalled(); got != want {
+			t.Errorf("Pitch.IsCalled returned %v for %v, want %v", got, des, want)
+		}
+	}
+}